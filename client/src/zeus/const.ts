@@ -562,7 +562,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'marketplace_cft20_detail_bool_exp',
     _not: 'marketplace_cft20_detail_bool_exp',
     _or: 'marketplace_cft20_detail_bool_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     date_created: 'timestamp_comparison_exp',
     id: 'Int_comparison_exp',
     listing_id: 'Int_comparison_exp',
@@ -624,7 +624,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   marketplace_cft20_detail_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     ppt: 'bigint',
   },
@@ -683,7 +683,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'marketplace_cft20_trade_history_bool_exp',
     _not: 'marketplace_cft20_trade_history_bool_exp',
     _or: 'marketplace_cft20_trade_history_bool_exp',
-    amount_base: 'bigint_comparison_exp',
+    amount_base: 'numeric_comparison_exp',
     amount_quote: 'bigint_comparison_exp',
     buyer_address: 'String_comparison_exp',
     date_created: 'timestamp_comparison_exp',
@@ -776,7 +776,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   marketplace_cft20_trade_history_stream_cursor_value_input: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     date_created: 'timestamp',
   },
@@ -1723,7 +1723,7 @@ export const AllTypesProps: Record<string, any> = {
     _not: 'token_address_history_bool_exp',
     _or: 'token_address_history_bool_exp',
     action: 'String_comparison_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     chain_id: 'String_comparison_exp',
     date_created: 'timestamp_comparison_exp',
     height: 'Int_comparison_exp',
@@ -1800,7 +1800,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_address_history_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
   },
   token_address_history_sum_order_by: {
@@ -1875,7 +1875,7 @@ export const AllTypesProps: Record<string, any> = {
     _not: 'token_bool_exp',
     _or: 'token_bool_exp',
     chain_id: 'String_comparison_exp',
-    circulating_supply: 'bigint_comparison_exp',
+    circulating_supply: 'numeric_comparison_exp',
     content_path: 'String_comparison_exp',
     content_size_bytes: 'Int_comparison_exp',
     creator: 'String_comparison_exp',
@@ -1895,7 +1895,7 @@ export const AllTypesProps: Record<string, any> = {
     metadata: 'String_comparison_exp',
     mint_page: 'String_comparison_exp',
     name: 'String_comparison_exp',
-    per_mint_limit: 'bigint_comparison_exp',
+    per_mint_limit: 'numeric_comparison_exp',
     ticker: 'String_comparison_exp',
     token_address_histories: 'token_address_history_bool_exp',
     token_holders: 'token_holder_bool_exp',
@@ -1943,7 +1943,7 @@ export const AllTypesProps: Record<string, any> = {
     _not: 'token_holder_bool_exp',
     _or: 'token_holder_bool_exp',
     address: 'String_comparison_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     chain_id: 'String_comparison_exp',
     date_updated: 'timestamp_comparison_exp',
     id: 'Int_comparison_exp',
@@ -1996,7 +1996,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_holder_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_updated: 'timestamp',
   },
   token_holder_sum_order_by: {
@@ -2090,7 +2090,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'token_open_position_bool_exp',
     _not: 'token_open_position_bool_exp',
     _or: 'token_open_position_bool_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     chain_id: 'String_comparison_exp',
     date_created: 'timestamp_comparison_exp',
     date_filled: 'timestamp_comparison_exp',
@@ -2175,7 +2175,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_open_position_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     date_filled: 'timestamp',
     ppt: 'bigint',
@@ -2291,13 +2291,13 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_stream_cursor_value_input: {
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     date_created: 'timestamp',
     decimals: 'smallint',
     last_price_base: 'bigint',
     launch_timestamp: 'bigint',
     max_supply: 'numeric',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     volume_24_base: 'bigint',
   },
   token_sum_order_by: {
@@ -2339,7 +2339,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'token_trade_history_bool_exp',
     _not: 'token_trade_history_bool_exp',
     _or: 'token_trade_history_bool_exp',
-    amount_base: 'bigint_comparison_exp',
+    amount_base: 'numeric_comparison_exp',
     amount_quote: 'bigint_comparison_exp',
     buyer_address: 'String_comparison_exp',
     chain_id: 'String_comparison_exp',
@@ -2427,7 +2427,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_trade_history_stream_cursor_value_input: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     date_created: 'timestamp',
   },
@@ -2932,7 +2932,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   json: `scalar.json` as const,
   marketplace_cft20_detail: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     id: 'Int',
     listing_id: 'Int',
@@ -2966,7 +2966,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Float',
   },
   marketplace_cft20_detail_max_fields: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     id: 'Int',
     listing_id: 'Int',
@@ -2974,7 +2974,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Int',
   },
   marketplace_cft20_detail_min_fields: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     id: 'Int',
     listing_id: 'Int',
@@ -3003,7 +3003,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Float',
   },
   marketplace_cft20_detail_sum_fields: {
-    amount: 'bigint',
+    amount: 'numeric',
     id: 'Int',
     listing_id: 'Int',
     ppt: 'bigint',
@@ -3031,7 +3031,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Float',
   },
   marketplace_cft20_trade_history: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     buyer_address: 'String',
     date_created: 'timestamp',
@@ -3283,7 +3283,7 @@ export const ReturnTypes: Record<string, any> = {
   timestamp: `scalar.timestamp` as const,
   token: {
     chain_id: 'String',
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     content_path: 'String',
     content_size_bytes: 'Int',
     creator: 'String',
@@ -3301,7 +3301,7 @@ export const ReturnTypes: Record<string, any> = {
     metadata: 'String',
     mint_page: 'String',
     name: 'String',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     ticker: 'String',
     token_address_histories: 'token_address_history',
     token_holders: 'token_holder',
@@ -3315,7 +3315,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_address_history: {
     action: 'String',
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_created: 'timestamp',
     height: 'Int',
@@ -3359,7 +3359,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_holder: {
     address: 'String',
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_updated: 'timestamp',
     id: 'Int',
@@ -3390,7 +3390,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_holder_max_fields: {
     address: 'String',
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_updated: 'timestamp',
     id: 'Int',
@@ -3398,7 +3398,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_holder_min_fields: {
     address: 'String',
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_updated: 'timestamp',
     id: 'Int',
@@ -3420,7 +3420,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Float',
   },
   token_holder_sum_fields: {
-    amount: 'bigint',
+    amount: 'numeric',
     id: 'Int',
     token_id: 'Int',
   },
@@ -3441,7 +3441,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_max_fields: {
     chain_id: 'String',
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     content_path: 'String',
     content_size_bytes: 'Int',
     creator: 'String',
@@ -3456,7 +3456,7 @@ export const ReturnTypes: Record<string, any> = {
     metadata: 'String',
     mint_page: 'String',
     name: 'String',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     ticker: 'String',
     transaction_id: 'Int',
     version: 'String',
@@ -3464,7 +3464,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_min_fields: {
     chain_id: 'String',
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     content_path: 'String',
     content_size_bytes: 'Int',
     creator: 'String',
@@ -3479,14 +3479,14 @@ export const ReturnTypes: Record<string, any> = {
     metadata: 'String',
     mint_page: 'String',
     name: 'String',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     ticker: 'String',
     transaction_id: 'Int',
     version: 'String',
     volume_24_base: 'bigint',
   },
   token_open_position: {
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_created: 'timestamp',
     date_filled: 'timestamp',
@@ -3541,7 +3541,7 @@ export const ReturnTypes: Record<string, any> = {
     volume_24_base: 'Float',
   },
   token_sum_fields: {
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     content_size_bytes: 'Int',
     decimals: 'smallint',
     height: 'Int',
@@ -3549,12 +3549,12 @@ export const ReturnTypes: Record<string, any> = {
     last_price_base: 'bigint',
     launch_timestamp: 'bigint',
     max_supply: 'numeric',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     transaction_id: 'Int',
     volume_24_base: 'bigint',
   },
   token_trade_history: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     buyer_address: 'String',
     chain_id: 'String',
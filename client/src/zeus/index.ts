@@ -3393,7 +3393,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -3594,7 +3594,7 @@ export type ValueTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>
     date_created?:
       | ValueTypes['timestamp']
       | undefined
@@ -3813,7 +3813,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     amount_base?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -4147,7 +4147,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
     amount_base?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>
@@ -8756,7 +8756,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -8938,7 +8938,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined | null | Variable<any, string>
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>
     chain_id?: string | undefined | null | Variable<any, string>
     date_created?:
       | ValueTypes['timestamp']
@@ -9190,7 +9190,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     circulating_supply?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -9280,7 +9280,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     per_mint_limit?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -9489,7 +9489,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -9640,7 +9640,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined | null | Variable<any, string>
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>
     chain_id?: string | undefined | null | Variable<any, string>
     date_updated?:
       | ValueTypes['timestamp']
@@ -10001,7 +10001,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -10234,7 +10234,7 @@ export type ValueTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>
     chain_id?: string | undefined | null | Variable<any, string>
     date_created?:
       | ValueTypes['timestamp']
@@ -10616,7 +10616,7 @@ export type ValueTypes = {
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined | null | Variable<any, string>
     circulating_supply?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>
@@ -10651,7 +10651,7 @@ export type ValueTypes = {
     mint_page?: string | undefined | null | Variable<any, string>
     name?: string | undefined | null | Variable<any, string>
     per_mint_limit?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>
@@ -10842,7 +10842,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>
     amount_base?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>
@@ -11139,7 +11139,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
     amount_base?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>
@@ -13579,7 +13579,7 @@ export type ResolverInputTypes = {
       | Array<ResolverInputTypes['marketplace_cft20_detail_bool_exp']>
       | undefined
       | null
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null
     date_created?:
       | ResolverInputTypes['timestamp_comparison_exp']
       | undefined
@@ -13708,7 +13708,7 @@ export type ResolverInputTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: ResolverInputTypes['bigint'] | undefined | null
+    amount?: ResolverInputTypes['numeric'] | undefined | null
     date_created?: ResolverInputTypes['timestamp'] | undefined | null
     id?: number | undefined | null
     listing_id?: number | undefined | null
@@ -13873,7 +13873,7 @@ export type ResolverInputTypes = {
       | Array<ResolverInputTypes['marketplace_cft20_trade_history_bool_exp']>
       | undefined
       | null
-    amount_base?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null
+    amount_base?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null
     amount_quote?:
       | ResolverInputTypes['bigint_comparison_exp']
       | undefined
@@ -13995,7 +13995,7 @@ export type ResolverInputTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
-    amount_base?: ResolverInputTypes['bigint'] | undefined | null
+    amount_base?: ResolverInputTypes['numeric'] | undefined | null
     amount_quote?: ResolverInputTypes['bigint'] | undefined | null
     buyer_address?: string | undefined | null
     date_created?: ResolverInputTypes['timestamp'] | undefined | null
@@ -17053,7 +17053,7 @@ export type ResolverInputTypes = {
       | undefined
       | null
     action?: ResolverInputTypes['String_comparison_exp'] | undefined | null
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null
     date_created?:
       | ResolverInputTypes['timestamp_comparison_exp']
@@ -17145,7 +17145,7 @@ export type ResolverInputTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined | null
-    amount?: ResolverInputTypes['bigint'] | undefined | null
+    amount?: ResolverInputTypes['numeric'] | undefined | null
     chain_id?: string | undefined | null
     date_created?: ResolverInputTypes['timestamp'] | undefined | null
     height?: number | undefined | null
@@ -17288,7 +17288,7 @@ export type ResolverInputTypes = {
     _or?: Array<ResolverInputTypes['token_bool_exp']> | undefined | null
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null
     circulating_supply?:
-      | ResolverInputTypes['bigint_comparison_exp']
+      | ResolverInputTypes['numeric_comparison_exp']
       | undefined
       | null
     content_path?:
@@ -17336,7 +17336,7 @@ export type ResolverInputTypes = {
     mint_page?: ResolverInputTypes['String_comparison_exp'] | undefined | null
     name?: ResolverInputTypes['String_comparison_exp'] | undefined | null
     per_mint_limit?:
-      | ResolverInputTypes['bigint_comparison_exp']
+      | ResolverInputTypes['numeric_comparison_exp']
       | undefined
       | null
     ticker?: ResolverInputTypes['String_comparison_exp'] | undefined | null
@@ -17476,7 +17476,7 @@ export type ResolverInputTypes = {
     _not?: ResolverInputTypes['token_holder_bool_exp'] | undefined | null
     _or?: Array<ResolverInputTypes['token_holder_bool_exp']> | undefined | null
     address?: ResolverInputTypes['String_comparison_exp'] | undefined | null
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null
     date_updated?:
       | ResolverInputTypes['timestamp_comparison_exp']
@@ -17585,7 +17585,7 @@ export type ResolverInputTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined | null
-    amount?: ResolverInputTypes['bigint'] | undefined | null
+    amount?: ResolverInputTypes['numeric'] | undefined | null
     chain_id?: string | undefined | null
     date_updated?: ResolverInputTypes['timestamp'] | undefined | null
     id?: number | undefined | null
@@ -17825,7 +17825,7 @@ export type ResolverInputTypes = {
       | Array<ResolverInputTypes['token_open_position_bool_exp']>
       | undefined
       | null
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null
     date_created?:
       | ResolverInputTypes['timestamp_comparison_exp']
@@ -17933,7 +17933,7 @@ export type ResolverInputTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: ResolverInputTypes['bigint'] | undefined | null
+    amount?: ResolverInputTypes['numeric'] | undefined | null
     chain_id?: string | undefined | null
     date_created?: ResolverInputTypes['timestamp'] | undefined | null
     date_filled?: ResolverInputTypes['timestamp'] | undefined | null
@@ -18130,7 +18130,7 @@ export type ResolverInputTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined | null
-    circulating_supply?: ResolverInputTypes['bigint'] | undefined | null
+    circulating_supply?: ResolverInputTypes['numeric'] | undefined | null
     content_path?: string | undefined | null
     content_size_bytes?: number | undefined | null
     creator?: string | undefined | null
@@ -18145,7 +18145,7 @@ export type ResolverInputTypes = {
     metadata?: string | undefined | null
     mint_page?: string | undefined | null
     name?: string | undefined | null
-    per_mint_limit?: ResolverInputTypes['bigint'] | undefined | null
+    per_mint_limit?: ResolverInputTypes['numeric'] | undefined | null
     ticker?: string | undefined | null
     transaction_id?: number | undefined | null
     version?: string | undefined | null
@@ -18264,7 +18264,7 @@ export type ResolverInputTypes = {
       | Array<ResolverInputTypes['token_trade_history_bool_exp']>
       | undefined
       | null
-    amount_base?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null
+    amount_base?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null
     amount_quote?:
       | ResolverInputTypes['bigint_comparison_exp']
       | undefined
@@ -18375,7 +18375,7 @@ export type ResolverInputTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
-    amount_base?: ResolverInputTypes['bigint'] | undefined | null
+    amount_base?: ResolverInputTypes['numeric'] | undefined | null
     amount_quote?: ResolverInputTypes['bigint'] | undefined | null
     buyer_address?: string | undefined | null
     chain_id?: string | undefined | null
@@ -19986,7 +19986,7 @@ export type ModelTypes = {
   }
   /** columns and relationships of "marketplace_cft20_detail" */
   ['marketplace_cft20_detail']: {
-    amount: ModelTypes['bigint']
+    amount: ModelTypes['numeric']
     date_created: ModelTypes['timestamp']
     id: number
     listing_id: number
@@ -20084,7 +20084,7 @@ export type ModelTypes = {
     _and?: Array<ModelTypes['marketplace_cft20_detail_bool_exp']> | undefined
     _not?: ModelTypes['marketplace_cft20_detail_bool_exp'] | undefined
     _or?: Array<ModelTypes['marketplace_cft20_detail_bool_exp']> | undefined
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined
     id?: ModelTypes['Int_comparison_exp'] | undefined
     listing_id?: ModelTypes['Int_comparison_exp'] | undefined
@@ -20095,7 +20095,7 @@ export type ModelTypes = {
   }
   /** aggregate max on columns */
   ['marketplace_cft20_detail_max_fields']: {
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     date_created?: ModelTypes['timestamp'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
@@ -20113,7 +20113,7 @@ export type ModelTypes = {
   }
   /** aggregate min on columns */
   ['marketplace_cft20_detail_min_fields']: {
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     date_created?: ModelTypes['timestamp'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
@@ -20198,7 +20198,7 @@ export type ModelTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     date_created?: ModelTypes['timestamp'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
@@ -20207,7 +20207,7 @@ export type ModelTypes = {
   }
   /** aggregate sum on columns */
   ['marketplace_cft20_detail_sum_fields']: {
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
     ppt?: ModelTypes['bigint'] | undefined
@@ -20271,7 +20271,7 @@ export type ModelTypes = {
   }
   /** columns and relationships of "marketplace_cft20_trade_history" */
   ['marketplace_cft20_trade_history']: {
-    amount_base: ModelTypes['bigint']
+    amount_base: ModelTypes['numeric']
     amount_quote: ModelTypes['bigint']
     buyer_address?: string | undefined
     date_created: ModelTypes['timestamp']
@@ -20335,7 +20335,7 @@ export type ModelTypes = {
     _or?:
       | Array<ModelTypes['marketplace_cft20_trade_history_bool_exp']>
       | undefined
-    amount_base?: ModelTypes['bigint_comparison_exp'] | undefined
+    amount_base?: ModelTypes['numeric_comparison_exp'] | undefined
     amount_quote?: ModelTypes['bigint_comparison_exp'] | undefined
     buyer_address?: ModelTypes['String_comparison_exp'] | undefined
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined
@@ -20438,7 +20438,7 @@ export type ModelTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
-    amount_base?: ModelTypes['bigint'] | undefined
+    amount_base?: ModelTypes['numeric'] | undefined
     amount_quote?: ModelTypes['bigint'] | undefined
     buyer_address?: string | undefined
     date_created?: ModelTypes['timestamp'] | undefined
@@ -21509,7 +21509,7 @@ export type ModelTypes = {
   /** columns and relationships of "token" */
   ['token']: {
     chain_id: string
-    circulating_supply: ModelTypes['bigint']
+    circulating_supply: ModelTypes['numeric']
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator: string
@@ -21532,7 +21532,7 @@ export type ModelTypes = {
     metadata?: string | undefined
     mint_page: string
     name: string
-    per_mint_limit: ModelTypes['bigint']
+    per_mint_limit: ModelTypes['numeric']
     ticker: string
     /** An array relationship */
     token_address_histories: Array<ModelTypes['token_address_history']>
@@ -21553,7 +21553,7 @@ export type ModelTypes = {
   /** columns and relationships of "token_address_history" */
   ['token_address_history']: {
     action: string
-    amount: ModelTypes['bigint']
+    amount: ModelTypes['numeric']
     chain_id: string
     date_created: ModelTypes['timestamp']
     height: number
@@ -21599,7 +21599,7 @@ export type ModelTypes = {
     _not?: ModelTypes['token_address_history_bool_exp'] | undefined
     _or?: Array<ModelTypes['token_address_history_bool_exp']> | undefined
     action?: ModelTypes['String_comparison_exp'] | undefined
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined
     chain_id?: ModelTypes['String_comparison_exp'] | undefined
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined
     height?: ModelTypes['Int_comparison_exp'] | undefined
@@ -21687,7 +21687,7 @@ export type ModelTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_created?: ModelTypes['timestamp'] | undefined
     height?: number | undefined
@@ -21805,7 +21805,7 @@ export type ModelTypes = {
     _not?: ModelTypes['token_bool_exp'] | undefined
     _or?: Array<ModelTypes['token_bool_exp']> | undefined
     chain_id?: ModelTypes['String_comparison_exp'] | undefined
-    circulating_supply?: ModelTypes['bigint_comparison_exp'] | undefined
+    circulating_supply?: ModelTypes['numeric_comparison_exp'] | undefined
     content_path?: ModelTypes['String_comparison_exp'] | undefined
     content_size_bytes?: ModelTypes['Int_comparison_exp'] | undefined
     creator?: ModelTypes['String_comparison_exp'] | undefined
@@ -21829,7 +21829,7 @@ export type ModelTypes = {
     metadata?: ModelTypes['String_comparison_exp'] | undefined
     mint_page?: ModelTypes['String_comparison_exp'] | undefined
     name?: ModelTypes['String_comparison_exp'] | undefined
-    per_mint_limit?: ModelTypes['bigint_comparison_exp'] | undefined
+    per_mint_limit?: ModelTypes['numeric_comparison_exp'] | undefined
     ticker?: ModelTypes['String_comparison_exp'] | undefined
     token_address_histories?:
       | ModelTypes['token_address_history_bool_exp']
@@ -21852,7 +21852,7 @@ export type ModelTypes = {
   /** columns and relationships of "token_holder" */
   ['token_holder']: {
     address: string
-    amount: ModelTypes['bigint']
+    amount: ModelTypes['numeric']
     chain_id: string
     date_updated: ModelTypes['timestamp']
     id: number
@@ -21920,7 +21920,7 @@ export type ModelTypes = {
     _not?: ModelTypes['token_holder_bool_exp'] | undefined
     _or?: Array<ModelTypes['token_holder_bool_exp']> | undefined
     address?: ModelTypes['String_comparison_exp'] | undefined
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined
     chain_id?: ModelTypes['String_comparison_exp'] | undefined
     date_updated?: ModelTypes['timestamp_comparison_exp'] | undefined
     id?: ModelTypes['Int_comparison_exp'] | undefined
@@ -21930,7 +21930,7 @@ export type ModelTypes = {
   /** aggregate max on columns */
   ['token_holder_max_fields']: {
     address?: string | undefined
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_updated?: ModelTypes['timestamp'] | undefined
     id?: number | undefined
@@ -21948,7 +21948,7 @@ export type ModelTypes = {
   /** aggregate min on columns */
   ['token_holder_min_fields']: {
     address?: string | undefined
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_updated?: ModelTypes['timestamp'] | undefined
     id?: number | undefined
@@ -22020,7 +22020,7 @@ export type ModelTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_updated?: ModelTypes['timestamp'] | undefined
     id?: number | undefined
@@ -22028,7 +22028,7 @@ export type ModelTypes = {
   }
   /** aggregate sum on columns */
   ['token_holder_sum_fields']: {
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     id?: number | undefined
     token_id?: number | undefined
   }
@@ -22077,7 +22077,7 @@ export type ModelTypes = {
   /** aggregate max on columns */
   ['token_max_fields']: {
     chain_id?: string | undefined
-    circulating_supply?: ModelTypes['bigint'] | undefined
+    circulating_supply?: ModelTypes['numeric'] | undefined
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator?: string | undefined
@@ -22092,7 +22092,7 @@ export type ModelTypes = {
     metadata?: string | undefined
     mint_page?: string | undefined
     name?: string | undefined
-    per_mint_limit?: ModelTypes['bigint'] | undefined
+    per_mint_limit?: ModelTypes['numeric'] | undefined
     ticker?: string | undefined
     transaction_id?: number | undefined
     version?: string | undefined
@@ -22125,7 +22125,7 @@ export type ModelTypes = {
   /** aggregate min on columns */
   ['token_min_fields']: {
     chain_id?: string | undefined
-    circulating_supply?: ModelTypes['bigint'] | undefined
+    circulating_supply?: ModelTypes['numeric'] | undefined
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator?: string | undefined
@@ -22140,7 +22140,7 @@ export type ModelTypes = {
     metadata?: string | undefined
     mint_page?: string | undefined
     name?: string | undefined
-    per_mint_limit?: ModelTypes['bigint'] | undefined
+    per_mint_limit?: ModelTypes['numeric'] | undefined
     ticker?: string | undefined
     transaction_id?: number | undefined
     version?: string | undefined
@@ -22172,7 +22172,7 @@ export type ModelTypes = {
   }
   /** columns and relationships of "token_open_position" */
   ['token_open_position']: {
-    amount: ModelTypes['bigint']
+    amount: ModelTypes['numeric']
     chain_id: string
     date_created: ModelTypes['timestamp']
     date_filled?: ModelTypes['timestamp'] | undefined
@@ -22221,7 +22221,7 @@ export type ModelTypes = {
     _and?: Array<ModelTypes['token_open_position_bool_exp']> | undefined
     _not?: ModelTypes['token_open_position_bool_exp'] | undefined
     _or?: Array<ModelTypes['token_open_position_bool_exp']> | undefined
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined
     chain_id?: ModelTypes['String_comparison_exp'] | undefined
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined
     date_filled?: ModelTypes['timestamp_comparison_exp'] | undefined
@@ -22316,7 +22316,7 @@ export type ModelTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: ModelTypes['bigint'] | undefined
+    amount?: ModelTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_created?: ModelTypes['timestamp'] | undefined
     date_filled?: ModelTypes['timestamp'] | undefined
@@ -22503,7 +22503,7 @@ export type ModelTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined
-    circulating_supply?: ModelTypes['bigint'] | undefined
+    circulating_supply?: ModelTypes['numeric'] | undefined
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator?: string | undefined
@@ -22518,7 +22518,7 @@ export type ModelTypes = {
     metadata?: string | undefined
     mint_page?: string | undefined
     name?: string | undefined
-    per_mint_limit?: ModelTypes['bigint'] | undefined
+    per_mint_limit?: ModelTypes['numeric'] | undefined
     ticker?: string | undefined
     transaction_id?: number | undefined
     version?: string | undefined
@@ -22526,7 +22526,7 @@ export type ModelTypes = {
   }
   /** aggregate sum on columns */
   ['token_sum_fields']: {
-    circulating_supply?: ModelTypes['bigint'] | undefined
+    circulating_supply?: ModelTypes['numeric'] | undefined
     content_size_bytes?: number | undefined
     decimals?: ModelTypes['smallint'] | undefined
     height?: number | undefined
@@ -22534,7 +22534,7 @@ export type ModelTypes = {
     last_price_base?: ModelTypes['bigint'] | undefined
     launch_timestamp?: ModelTypes['bigint'] | undefined
     max_supply?: ModelTypes['numeric'] | undefined
-    per_mint_limit?: ModelTypes['bigint'] | undefined
+    per_mint_limit?: ModelTypes['numeric'] | undefined
     transaction_id?: number | undefined
     volume_24_base?: ModelTypes['bigint'] | undefined
   }
@@ -22554,7 +22554,7 @@ export type ModelTypes = {
   }
   /** columns and relationships of "token_trade_history" */
   ['token_trade_history']: {
-    amount_base: ModelTypes['bigint']
+    amount_base: ModelTypes['numeric']
     amount_quote: ModelTypes['bigint']
     buyer_address?: string | undefined
     chain_id: string
@@ -22603,7 +22603,7 @@ export type ModelTypes = {
     _and?: Array<ModelTypes['token_trade_history_bool_exp']> | undefined
     _not?: ModelTypes['token_trade_history_bool_exp'] | undefined
     _or?: Array<ModelTypes['token_trade_history_bool_exp']> | undefined
-    amount_base?: ModelTypes['bigint_comparison_exp'] | undefined
+    amount_base?: ModelTypes['numeric_comparison_exp'] | undefined
     amount_quote?: ModelTypes['bigint_comparison_exp'] | undefined
     buyer_address?: ModelTypes['String_comparison_exp'] | undefined
     chain_id?: ModelTypes['String_comparison_exp'] | undefined
@@ -22701,7 +22701,7 @@ export type ModelTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
-    amount_base?: ModelTypes['bigint'] | undefined
+    amount_base?: ModelTypes['numeric'] | undefined
     amount_quote?: ModelTypes['bigint'] | undefined
     buyer_address?: string | undefined
     chain_id?: string | undefined
@@ -24083,7 +24083,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "marketplace_cft20_detail" */
   ['marketplace_cft20_detail']: {
     __typename: 'marketplace_cft20_detail'
-    amount: GraphQLTypes['bigint']
+    amount: GraphQLTypes['numeric']
     date_created: GraphQLTypes['timestamp']
     id: number
     listing_id: number
@@ -24188,7 +24188,7 @@ export type GraphQLTypes = {
     _and?: Array<GraphQLTypes['marketplace_cft20_detail_bool_exp']> | undefined
     _not?: GraphQLTypes['marketplace_cft20_detail_bool_exp'] | undefined
     _or?: Array<GraphQLTypes['marketplace_cft20_detail_bool_exp']> | undefined
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined
     id?: GraphQLTypes['Int_comparison_exp'] | undefined
     listing_id?: GraphQLTypes['Int_comparison_exp'] | undefined
@@ -24202,7 +24202,7 @@ export type GraphQLTypes = {
   /** aggregate max on columns */
   ['marketplace_cft20_detail_max_fields']: {
     __typename: 'marketplace_cft20_detail_max_fields'
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     date_created?: GraphQLTypes['timestamp'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
@@ -24221,7 +24221,7 @@ export type GraphQLTypes = {
   /** aggregate min on columns */
   ['marketplace_cft20_detail_min_fields']: {
     __typename: 'marketplace_cft20_detail_min_fields'
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     date_created?: GraphQLTypes['timestamp'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
@@ -24312,7 +24312,7 @@ export type GraphQLTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     date_created?: GraphQLTypes['timestamp'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
@@ -24322,7 +24322,7 @@ export type GraphQLTypes = {
   /** aggregate sum on columns */
   ['marketplace_cft20_detail_sum_fields']: {
     __typename: 'marketplace_cft20_detail_sum_fields'
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     id?: number | undefined
     listing_id?: number | undefined
     ppt?: GraphQLTypes['bigint'] | undefined
@@ -24390,7 +24390,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "marketplace_cft20_trade_history" */
   ['marketplace_cft20_trade_history']: {
     __typename: 'marketplace_cft20_trade_history'
-    amount_base: GraphQLTypes['bigint']
+    amount_base: GraphQLTypes['numeric']
     amount_quote: GraphQLTypes['bigint']
     buyer_address?: string | undefined
     date_created: GraphQLTypes['timestamp']
@@ -24462,7 +24462,7 @@ export type GraphQLTypes = {
     _or?:
       | Array<GraphQLTypes['marketplace_cft20_trade_history_bool_exp']>
       | undefined
-    amount_base?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    amount_base?: GraphQLTypes['numeric_comparison_exp'] | undefined
     amount_quote?: GraphQLTypes['bigint_comparison_exp'] | undefined
     buyer_address?: GraphQLTypes['String_comparison_exp'] | undefined
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined
@@ -24570,7 +24570,7 @@ export type GraphQLTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
-    amount_base?: GraphQLTypes['bigint'] | undefined
+    amount_base?: GraphQLTypes['numeric'] | undefined
     amount_quote?: GraphQLTypes['bigint'] | undefined
     buyer_address?: string | undefined
     date_created?: GraphQLTypes['timestamp'] | undefined
@@ -25686,7 +25686,7 @@ export type GraphQLTypes = {
   ['token']: {
     __typename: 'token'
     chain_id: string
-    circulating_supply: GraphQLTypes['bigint']
+    circulating_supply: GraphQLTypes['numeric']
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator: string
@@ -25709,7 +25709,7 @@ export type GraphQLTypes = {
     metadata?: string | undefined
     mint_page: string
     name: string
-    per_mint_limit: GraphQLTypes['bigint']
+    per_mint_limit: GraphQLTypes['numeric']
     ticker: string
     /** An array relationship */
     token_address_histories: Array<GraphQLTypes['token_address_history']>
@@ -25731,7 +25731,7 @@ export type GraphQLTypes = {
   ['token_address_history']: {
     __typename: 'token_address_history'
     action: string
-    amount: GraphQLTypes['bigint']
+    amount: GraphQLTypes['numeric']
     chain_id: string
     date_created: GraphQLTypes['timestamp']
     height: number
@@ -25781,7 +25781,7 @@ export type GraphQLTypes = {
     _not?: GraphQLTypes['token_address_history_bool_exp'] | undefined
     _or?: Array<GraphQLTypes['token_address_history_bool_exp']> | undefined
     action?: GraphQLTypes['String_comparison_exp'] | undefined
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined
     height?: GraphQLTypes['Int_comparison_exp'] | undefined
@@ -25870,7 +25870,7 @@ export type GraphQLTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_created?: GraphQLTypes['timestamp'] | undefined
     height?: number | undefined
@@ -25991,7 +25991,7 @@ export type GraphQLTypes = {
     _not?: GraphQLTypes['token_bool_exp'] | undefined
     _or?: Array<GraphQLTypes['token_bool_exp']> | undefined
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined
-    circulating_supply?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    circulating_supply?: GraphQLTypes['numeric_comparison_exp'] | undefined
     content_path?: GraphQLTypes['String_comparison_exp'] | undefined
     content_size_bytes?: GraphQLTypes['Int_comparison_exp'] | undefined
     creator?: GraphQLTypes['String_comparison_exp'] | undefined
@@ -26015,7 +26015,7 @@ export type GraphQLTypes = {
     metadata?: GraphQLTypes['String_comparison_exp'] | undefined
     mint_page?: GraphQLTypes['String_comparison_exp'] | undefined
     name?: GraphQLTypes['String_comparison_exp'] | undefined
-    per_mint_limit?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    per_mint_limit?: GraphQLTypes['numeric_comparison_exp'] | undefined
     ticker?: GraphQLTypes['String_comparison_exp'] | undefined
     token_address_histories?:
       | GraphQLTypes['token_address_history_bool_exp']
@@ -26039,7 +26039,7 @@ export type GraphQLTypes = {
   ['token_holder']: {
     __typename: 'token_holder'
     address: string
-    amount: GraphQLTypes['bigint']
+    amount: GraphQLTypes['numeric']
     chain_id: string
     date_updated: GraphQLTypes['timestamp']
     id: number
@@ -26110,7 +26110,7 @@ export type GraphQLTypes = {
     _not?: GraphQLTypes['token_holder_bool_exp'] | undefined
     _or?: Array<GraphQLTypes['token_holder_bool_exp']> | undefined
     address?: GraphQLTypes['String_comparison_exp'] | undefined
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined
     date_updated?: GraphQLTypes['timestamp_comparison_exp'] | undefined
     id?: GraphQLTypes['Int_comparison_exp'] | undefined
@@ -26121,7 +26121,7 @@ export type GraphQLTypes = {
   ['token_holder_max_fields']: {
     __typename: 'token_holder_max_fields'
     address?: string | undefined
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_updated?: GraphQLTypes['timestamp'] | undefined
     id?: number | undefined
@@ -26140,7 +26140,7 @@ export type GraphQLTypes = {
   ['token_holder_min_fields']: {
     __typename: 'token_holder_min_fields'
     address?: string | undefined
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_updated?: GraphQLTypes['timestamp'] | undefined
     id?: number | undefined
@@ -26216,7 +26216,7 @@ export type GraphQLTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_updated?: GraphQLTypes['timestamp'] | undefined
     id?: number | undefined
@@ -26225,7 +26225,7 @@ export type GraphQLTypes = {
   /** aggregate sum on columns */
   ['token_holder_sum_fields']: {
     __typename: 'token_holder_sum_fields'
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     id?: number | undefined
     token_id?: number | undefined
   }
@@ -26278,7 +26278,7 @@ export type GraphQLTypes = {
   ['token_max_fields']: {
     __typename: 'token_max_fields'
     chain_id?: string | undefined
-    circulating_supply?: GraphQLTypes['bigint'] | undefined
+    circulating_supply?: GraphQLTypes['numeric'] | undefined
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator?: string | undefined
@@ -26293,7 +26293,7 @@ export type GraphQLTypes = {
     metadata?: string | undefined
     mint_page?: string | undefined
     name?: string | undefined
-    per_mint_limit?: GraphQLTypes['bigint'] | undefined
+    per_mint_limit?: GraphQLTypes['numeric'] | undefined
     ticker?: string | undefined
     transaction_id?: number | undefined
     version?: string | undefined
@@ -26327,7 +26327,7 @@ export type GraphQLTypes = {
   ['token_min_fields']: {
     __typename: 'token_min_fields'
     chain_id?: string | undefined
-    circulating_supply?: GraphQLTypes['bigint'] | undefined
+    circulating_supply?: GraphQLTypes['numeric'] | undefined
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator?: string | undefined
@@ -26342,7 +26342,7 @@ export type GraphQLTypes = {
     metadata?: string | undefined
     mint_page?: string | undefined
     name?: string | undefined
-    per_mint_limit?: GraphQLTypes['bigint'] | undefined
+    per_mint_limit?: GraphQLTypes['numeric'] | undefined
     ticker?: string | undefined
     transaction_id?: number | undefined
     version?: string | undefined
@@ -26375,7 +26375,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "token_open_position" */
   ['token_open_position']: {
     __typename: 'token_open_position'
-    amount: GraphQLTypes['bigint']
+    amount: GraphQLTypes['numeric']
     chain_id: string
     date_created: GraphQLTypes['timestamp']
     date_filled?: GraphQLTypes['timestamp'] | undefined
@@ -26424,7 +26424,7 @@ export type GraphQLTypes = {
     _and?: Array<GraphQLTypes['token_open_position_bool_exp']> | undefined
     _not?: GraphQLTypes['token_open_position_bool_exp'] | undefined
     _or?: Array<GraphQLTypes['token_open_position_bool_exp']> | undefined
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined
     date_filled?: GraphQLTypes['timestamp_comparison_exp'] | undefined
@@ -26520,7 +26520,7 @@ export type GraphQLTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: GraphQLTypes['bigint'] | undefined
+    amount?: GraphQLTypes['numeric'] | undefined
     chain_id?: string | undefined
     date_created?: GraphQLTypes['timestamp'] | undefined
     date_filled?: GraphQLTypes['timestamp'] | undefined
@@ -26711,7 +26711,7 @@ export type GraphQLTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined
-    circulating_supply?: GraphQLTypes['bigint'] | undefined
+    circulating_supply?: GraphQLTypes['numeric'] | undefined
     content_path?: string | undefined
     content_size_bytes?: number | undefined
     creator?: string | undefined
@@ -26726,7 +26726,7 @@ export type GraphQLTypes = {
     metadata?: string | undefined
     mint_page?: string | undefined
     name?: string | undefined
-    per_mint_limit?: GraphQLTypes['bigint'] | undefined
+    per_mint_limit?: GraphQLTypes['numeric'] | undefined
     ticker?: string | undefined
     transaction_id?: number | undefined
     version?: string | undefined
@@ -26735,7 +26735,7 @@ export type GraphQLTypes = {
   /** aggregate sum on columns */
   ['token_sum_fields']: {
     __typename: 'token_sum_fields'
-    circulating_supply?: GraphQLTypes['bigint'] | undefined
+    circulating_supply?: GraphQLTypes['numeric'] | undefined
     content_size_bytes?: number | undefined
     decimals?: GraphQLTypes['smallint'] | undefined
     height?: number | undefined
@@ -26743,7 +26743,7 @@ export type GraphQLTypes = {
     last_price_base?: GraphQLTypes['bigint'] | undefined
     launch_timestamp?: GraphQLTypes['bigint'] | undefined
     max_supply?: GraphQLTypes['numeric'] | undefined
-    per_mint_limit?: GraphQLTypes['bigint'] | undefined
+    per_mint_limit?: GraphQLTypes['numeric'] | undefined
     transaction_id?: number | undefined
     volume_24_base?: GraphQLTypes['bigint'] | undefined
   }
@@ -26764,7 +26764,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "token_trade_history" */
   ['token_trade_history']: {
     __typename: 'token_trade_history'
-    amount_base: GraphQLTypes['bigint']
+    amount_base: GraphQLTypes['numeric']
     amount_quote: GraphQLTypes['bigint']
     buyer_address?: string | undefined
     chain_id: string
@@ -26813,7 +26813,7 @@ export type GraphQLTypes = {
     _and?: Array<GraphQLTypes['token_trade_history_bool_exp']> | undefined
     _not?: GraphQLTypes['token_trade_history_bool_exp'] | undefined
     _or?: Array<GraphQLTypes['token_trade_history_bool_exp']> | undefined
-    amount_base?: GraphQLTypes['bigint_comparison_exp'] | undefined
+    amount_base?: GraphQLTypes['numeric_comparison_exp'] | undefined
     amount_quote?: GraphQLTypes['bigint_comparison_exp'] | undefined
     buyer_address?: GraphQLTypes['String_comparison_exp'] | undefined
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined
@@ -26912,7 +26912,7 @@ export type GraphQLTypes = {
   }
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
-    amount_base?: GraphQLTypes['bigint'] | undefined
+    amount_base?: GraphQLTypes['numeric'] | undefined
     amount_quote?: GraphQLTypes['bigint'] | undefined
     buyer_address?: string | undefined
     chain_id?: string | undefined
@@ -394,7 +394,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'marketplace_cft20_detail_bool_exp',
     _not: 'marketplace_cft20_detail_bool_exp',
     _or: 'marketplace_cft20_detail_bool_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     date_created: 'timestamp_comparison_exp',
     id: 'Int_comparison_exp',
     listing_id: 'Int_comparison_exp',
@@ -456,7 +456,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   marketplace_cft20_detail_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     ppt: 'bigint',
   },
@@ -515,7 +515,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'marketplace_cft20_trade_history_bool_exp',
     _not: 'marketplace_cft20_trade_history_bool_exp',
     _or: 'marketplace_cft20_trade_history_bool_exp',
-    amount_base: 'bigint_comparison_exp',
+    amount_base: 'numeric_comparison_exp',
     amount_quote: 'bigint_comparison_exp',
     buyer_address: 'String_comparison_exp',
     date_created: 'timestamp_comparison_exp',
@@ -608,7 +608,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   marketplace_cft20_trade_history_stream_cursor_value_input: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     date_created: 'timestamp',
   },
@@ -1444,7 +1444,7 @@ export const AllTypesProps: Record<string, any> = {
     _not: 'token_address_history_bool_exp',
     _or: 'token_address_history_bool_exp',
     action: 'String_comparison_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     chain_id: 'String_comparison_exp',
     date_created: 'timestamp_comparison_exp',
     height: 'Int_comparison_exp',
@@ -1521,7 +1521,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_address_history_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
   },
   token_address_history_sum_order_by: {
@@ -1557,7 +1557,7 @@ export const AllTypesProps: Record<string, any> = {
     _not: 'token_bool_exp',
     _or: 'token_bool_exp',
     chain_id: 'String_comparison_exp',
-    circulating_supply: 'bigint_comparison_exp',
+    circulating_supply: 'numeric_comparison_exp',
     content_path: 'String_comparison_exp',
     content_size_bytes: 'Int_comparison_exp',
     creator: 'String_comparison_exp',
@@ -1575,7 +1575,7 @@ export const AllTypesProps: Record<string, any> = {
     metadata: 'String_comparison_exp',
     mint_page: 'String_comparison_exp',
     name: 'String_comparison_exp',
-    per_mint_limit: 'bigint_comparison_exp',
+    per_mint_limit: 'numeric_comparison_exp',
     ticker: 'String_comparison_exp',
     token_address_histories: 'token_address_history_bool_exp',
     token_holders: 'token_holder_bool_exp',
@@ -1609,7 +1609,7 @@ export const AllTypesProps: Record<string, any> = {
     _not: 'token_holder_bool_exp',
     _or: 'token_holder_bool_exp',
     address: 'String_comparison_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     chain_id: 'String_comparison_exp',
     date_updated: 'timestamp_comparison_exp',
     id: 'Int_comparison_exp',
@@ -1662,7 +1662,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_holder_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_updated: 'timestamp',
   },
   token_holder_sum_order_by: {
@@ -1711,7 +1711,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'token_open_position_bool_exp',
     _not: 'token_open_position_bool_exp',
     _or: 'token_open_position_bool_exp',
-    amount: 'bigint_comparison_exp',
+    amount: 'numeric_comparison_exp',
     chain_id: 'String_comparison_exp',
     date_created: 'timestamp_comparison_exp',
     date_filled: 'timestamp_comparison_exp',
@@ -1809,7 +1809,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_open_position_stream_cursor_value_input: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     date_filled: 'timestamp',
     ppt: 'bigint',
@@ -1890,13 +1890,13 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_stream_cursor_value_input: {
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     date_created: 'timestamp',
     decimals: 'smallint',
     last_price_base: 'bigint',
     launch_timestamp: 'bigint',
     max_supply: 'numeric',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     volume_24_base: 'bigint',
   },
   token_trade_history_aggregate_order_by: {
@@ -1925,7 +1925,7 @@ export const AllTypesProps: Record<string, any> = {
     _and: 'token_trade_history_bool_exp',
     _not: 'token_trade_history_bool_exp',
     _or: 'token_trade_history_bool_exp',
-    amount_base: 'bigint_comparison_exp',
+    amount_base: 'numeric_comparison_exp',
     amount_quote: 'bigint_comparison_exp',
     buyer_address: 'String_comparison_exp',
     chain_id: 'String_comparison_exp',
@@ -2013,7 +2013,7 @@ export const AllTypesProps: Record<string, any> = {
     ordering: 'cursor_ordering',
   },
   token_trade_history_stream_cursor_value_input: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     date_created: 'timestamp',
     rate: 'bigint',
@@ -2286,7 +2286,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   json: `scalar.json` as const,
   marketplace_cft20_detail: {
-    amount: 'bigint',
+    amount: 'numeric',
     date_created: 'timestamp',
     id: 'Int',
     listing_id: 'Int',
@@ -2296,7 +2296,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Int',
   },
   marketplace_cft20_trade_history: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     buyer_address: 'String',
     date_created: 'timestamp',
@@ -2459,7 +2459,7 @@ export const ReturnTypes: Record<string, any> = {
   timestamp: `scalar.timestamp` as const,
   token: {
     chain_id: 'String',
-    circulating_supply: 'bigint',
+    circulating_supply: 'numeric',
     content_path: 'String',
     content_size_bytes: 'Int',
     creator: 'String',
@@ -2476,7 +2476,7 @@ export const ReturnTypes: Record<string, any> = {
     metadata: 'String',
     mint_page: 'String',
     name: 'String',
-    per_mint_limit: 'bigint',
+    per_mint_limit: 'numeric',
     ticker: 'String',
     token_address_histories: 'token_address_history',
     token_holders: 'token_holder',
@@ -2489,7 +2489,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_address_history: {
     action: 'String',
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_created: 'timestamp',
     height: 'Int',
@@ -2503,7 +2503,7 @@ export const ReturnTypes: Record<string, any> = {
   },
   token_holder: {
     address: 'String',
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_updated: 'timestamp',
     id: 'Int',
@@ -2511,7 +2511,7 @@ export const ReturnTypes: Record<string, any> = {
     token_id: 'Int',
   },
   token_open_position: {
-    amount: 'bigint',
+    amount: 'numeric',
     chain_id: 'String',
     date_created: 'timestamp',
     date_filled: 'timestamp',
@@ -2530,7 +2530,7 @@ export const ReturnTypes: Record<string, any> = {
     transaction_id: 'Int',
   },
   token_trade_history: {
-    amount_base: 'bigint',
+    amount_base: 'numeric',
     amount_quote: 'bigint',
     buyer_address: 'String',
     chain_id: 'String',
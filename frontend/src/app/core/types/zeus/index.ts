@@ -2696,7 +2696,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -2874,7 +2874,7 @@ export type ValueTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>;
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>;
     date_created?:
       | ValueTypes['timestamp']
       | undefined
@@ -3077,7 +3077,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     amount_base?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -3435,7 +3435,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
     amount_base?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>;
@@ -7314,7 +7314,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -7544,7 +7544,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined | null | Variable<any, string>;
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>;
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>;
     chain_id?: string | undefined | null | Variable<any, string>;
     date_created?:
       | ValueTypes['timestamp']
@@ -7645,7 +7645,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     circulating_supply?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -7730,7 +7730,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     per_mint_limit?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -7879,7 +7879,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -8025,7 +8025,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined | null | Variable<any, string>;
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>;
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>;
     chain_id?: string | undefined | null | Variable<any, string>;
     date_updated?:
       | ValueTypes['timestamp']
@@ -8192,7 +8192,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     amount?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -8526,7 +8526,7 @@ export type ValueTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: ValueTypes['bigint'] | undefined | null | Variable<any, string>;
+    amount?: ValueTypes['numeric'] | undefined | null | Variable<any, string>;
     chain_id?: string | undefined | null | Variable<any, string>;
     date_created?:
       | ValueTypes['timestamp']
@@ -8776,7 +8776,7 @@ export type ValueTypes = {
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined | null | Variable<any, string>;
     circulating_supply?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>;
@@ -8815,7 +8815,7 @@ export type ValueTypes = {
     mint_page?: string | undefined | null | Variable<any, string>;
     name?: string | undefined | null | Variable<any, string>;
     per_mint_limit?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>;
@@ -8949,7 +8949,7 @@ export type ValueTypes = {
       | null
       | Variable<any, string>;
     amount_base?:
-      | ValueTypes['bigint_comparison_exp']
+      | ValueTypes['numeric_comparison_exp']
       | undefined
       | null
       | Variable<any, string>;
@@ -9282,7 +9282,7 @@ export type ValueTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
     amount_base?:
-      | ValueTypes['bigint']
+      | ValueTypes['numeric']
       | undefined
       | null
       | Variable<any, string>;
@@ -10838,7 +10838,7 @@ export type ResolverInputTypes = {
       | Array<ResolverInputTypes['marketplace_cft20_detail_bool_exp']>
       | undefined
       | null;
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null;
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null;
     date_created?:
       | ResolverInputTypes['timestamp_comparison_exp']
       | undefined
@@ -10920,7 +10920,7 @@ export type ResolverInputTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: ResolverInputTypes['bigint'] | undefined | null;
+    amount?: ResolverInputTypes['numeric'] | undefined | null;
     date_created?: ResolverInputTypes['timestamp'] | undefined | null;
     id?: number | undefined | null;
     listing_id?: number | undefined | null;
@@ -11050,7 +11050,7 @@ export type ResolverInputTypes = {
       | undefined
       | null;
     amount_base?:
-      | ResolverInputTypes['bigint_comparison_exp']
+      | ResolverInputTypes['numeric_comparison_exp']
       | undefined
       | null;
     amount_quote?:
@@ -11177,7 +11177,7 @@ export type ResolverInputTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
-    amount_base?: ResolverInputTypes['bigint'] | undefined | null;
+    amount_base?: ResolverInputTypes['numeric'] | undefined | null;
     amount_quote?: ResolverInputTypes['bigint'] | undefined | null;
     buyer_address?: string | undefined | null;
     date_created?: ResolverInputTypes['timestamp'] | undefined | null;
@@ -13682,7 +13682,7 @@ export type ResolverInputTypes = {
       | undefined
       | null;
     action?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null;
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null;
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
     date_created?:
       | ResolverInputTypes['timestamp_comparison_exp']
@@ -13777,7 +13777,7 @@ export type ResolverInputTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined | null;
-    amount?: ResolverInputTypes['bigint'] | undefined | null;
+    amount?: ResolverInputTypes['numeric'] | undefined | null;
     chain_id?: string | undefined | null;
     date_created?: ResolverInputTypes['timestamp'] | undefined | null;
     height?: number | undefined | null;
@@ -13826,7 +13826,7 @@ export type ResolverInputTypes = {
     _or?: Array<ResolverInputTypes['token_bool_exp']> | undefined | null;
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
     circulating_supply?:
-      | ResolverInputTypes['bigint_comparison_exp']
+      | ResolverInputTypes['numeric_comparison_exp']
       | undefined
       | null;
     content_path?:
@@ -13873,7 +13873,7 @@ export type ResolverInputTypes = {
     mint_page?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
     name?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
     per_mint_limit?:
-      | ResolverInputTypes['bigint_comparison_exp']
+      | ResolverInputTypes['numeric_comparison_exp']
       | undefined
       | null;
     ticker?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
@@ -13963,7 +13963,7 @@ export type ResolverInputTypes = {
     _not?: ResolverInputTypes['token_holder_bool_exp'] | undefined | null;
     _or?: Array<ResolverInputTypes['token_holder_bool_exp']> | undefined | null;
     address?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null;
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null;
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
     date_updated?:
       | ResolverInputTypes['timestamp_comparison_exp']
@@ -14031,7 +14031,7 @@ export type ResolverInputTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined | null;
-    amount?: ResolverInputTypes['bigint'] | undefined | null;
+    amount?: ResolverInputTypes['numeric'] | undefined | null;
     chain_id?: string | undefined | null;
     date_updated?: ResolverInputTypes['timestamp'] | undefined | null;
     id?: number | undefined | null;
@@ -14152,7 +14152,7 @@ export type ResolverInputTypes = {
       | Array<ResolverInputTypes['token_open_position_bool_exp']>
       | undefined
       | null;
-    amount?: ResolverInputTypes['bigint_comparison_exp'] | undefined | null;
+    amount?: ResolverInputTypes['numeric_comparison_exp'] | undefined | null;
     chain_id?: ResolverInputTypes['String_comparison_exp'] | undefined | null;
     date_created?:
       | ResolverInputTypes['timestamp_comparison_exp']
@@ -14285,7 +14285,7 @@ export type ResolverInputTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: ResolverInputTypes['bigint'] | undefined | null;
+    amount?: ResolverInputTypes['numeric'] | undefined | null;
     chain_id?: string | undefined | null;
     date_created?: ResolverInputTypes['timestamp'] | undefined | null;
     date_filled?: ResolverInputTypes['timestamp'] | undefined | null;
@@ -14402,7 +14402,7 @@ export type ResolverInputTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined | null;
-    circulating_supply?: ResolverInputTypes['bigint'] | undefined | null;
+    circulating_supply?: ResolverInputTypes['numeric'] | undefined | null;
     content_path?: string | undefined | null;
     content_size_bytes?: number | undefined | null;
     creator?: string | undefined | null;
@@ -14417,7 +14417,7 @@ export type ResolverInputTypes = {
     metadata?: string | undefined | null;
     mint_page?: string | undefined | null;
     name?: string | undefined | null;
-    per_mint_limit?: ResolverInputTypes['bigint'] | undefined | null;
+    per_mint_limit?: ResolverInputTypes['numeric'] | undefined | null;
     ticker?: string | undefined | null;
     transaction_id?: number | undefined | null;
     version?: string | undefined | null;
@@ -14511,7 +14511,7 @@ export type ResolverInputTypes = {
       | undefined
       | null;
     amount_base?:
-      | ResolverInputTypes['bigint_comparison_exp']
+      | ResolverInputTypes['numeric_comparison_exp']
       | undefined
       | null;
     amount_quote?:
@@ -14627,7 +14627,7 @@ export type ResolverInputTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
-    amount_base?: ResolverInputTypes['bigint'] | undefined | null;
+    amount_base?: ResolverInputTypes['numeric'] | undefined | null;
     amount_quote?: ResolverInputTypes['bigint'] | undefined | null;
     buyer_address?: string | undefined | null;
     chain_id?: string | undefined | null;
@@ -15645,7 +15645,7 @@ export type ModelTypes = {
   };
   /** columns and relationships of "marketplace_cft20_detail" */
   ['marketplace_cft20_detail']: {
-    amount: ModelTypes['bigint'];
+    amount: ModelTypes['numeric'];
     date_created: ModelTypes['timestamp'];
     id: number;
     listing_id: number;
@@ -15693,7 +15693,7 @@ export type ModelTypes = {
     _and?: Array<ModelTypes['marketplace_cft20_detail_bool_exp']> | undefined;
     _not?: ModelTypes['marketplace_cft20_detail_bool_exp'] | undefined;
     _or?: Array<ModelTypes['marketplace_cft20_detail_bool_exp']> | undefined;
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined;
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined;
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined;
     id?: ModelTypes['Int_comparison_exp'] | undefined;
     listing_id?: ModelTypes['Int_comparison_exp'] | undefined;
@@ -15769,7 +15769,7 @@ export type ModelTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: ModelTypes['bigint'] | undefined;
+    amount?: ModelTypes['numeric'] | undefined;
     date_created?: ModelTypes['timestamp'] | undefined;
     id?: number | undefined;
     listing_id?: number | undefined;
@@ -15810,7 +15810,7 @@ export type ModelTypes = {
   };
   /** columns and relationships of "marketplace_cft20_trade_history" */
   ['marketplace_cft20_trade_history']: {
-    amount_base: ModelTypes['bigint'];
+    amount_base: ModelTypes['numeric'];
     amount_quote: ModelTypes['bigint'];
     buyer_address?: string | undefined;
     date_created: ModelTypes['timestamp'];
@@ -15882,7 +15882,7 @@ export type ModelTypes = {
     _or?:
       | Array<ModelTypes['marketplace_cft20_trade_history_bool_exp']>
       | undefined;
-    amount_base?: ModelTypes['bigint_comparison_exp'] | undefined;
+    amount_base?: ModelTypes['numeric_comparison_exp'] | undefined;
     amount_quote?: ModelTypes['bigint_comparison_exp'] | undefined;
     buyer_address?: ModelTypes['String_comparison_exp'] | undefined;
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined;
@@ -15989,7 +15989,7 @@ export type ModelTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
-    amount_base?: ModelTypes['bigint'] | undefined;
+    amount_base?: ModelTypes['numeric'] | undefined;
     amount_quote?: ModelTypes['bigint'] | undefined;
     buyer_address?: string | undefined;
     date_created?: ModelTypes['timestamp'] | undefined;
@@ -16922,7 +16922,7 @@ export type ModelTypes = {
   /** columns and relationships of "token" */
   ['token']: {
     chain_id: string;
-    circulating_supply: ModelTypes['bigint'];
+    circulating_supply: ModelTypes['numeric'];
     content_path?: string | undefined;
     content_size_bytes?: number | undefined;
     creator: string;
@@ -16943,7 +16943,7 @@ export type ModelTypes = {
     metadata?: string | undefined;
     mint_page: string;
     name: string;
-    per_mint_limit: ModelTypes['bigint'];
+    per_mint_limit: ModelTypes['numeric'];
     ticker: string;
     /** An array relationship */
     token_address_histories: Array<ModelTypes['token_address_history']>;
@@ -16962,7 +16962,7 @@ export type ModelTypes = {
   /** columns and relationships of "token_address_history" */
   ['token_address_history']: {
     action: string;
-    amount: ModelTypes['bigint'];
+    amount: ModelTypes['numeric'];
     chain_id: string;
     date_created: ModelTypes['timestamp'];
     height: number;
@@ -17012,7 +17012,7 @@ export type ModelTypes = {
     _not?: ModelTypes['token_address_history_bool_exp'] | undefined;
     _or?: Array<ModelTypes['token_address_history_bool_exp']> | undefined;
     action?: ModelTypes['String_comparison_exp'] | undefined;
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined;
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined;
     chain_id?: ModelTypes['String_comparison_exp'] | undefined;
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined;
     height?: ModelTypes['Int_comparison_exp'] | undefined;
@@ -17100,7 +17100,7 @@ export type ModelTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined;
-    amount?: ModelTypes['bigint'] | undefined;
+    amount?: ModelTypes['numeric'] | undefined;
     chain_id?: string | undefined;
     date_created?: ModelTypes['timestamp'] | undefined;
     height?: number | undefined;
@@ -17148,7 +17148,7 @@ export type ModelTypes = {
     _not?: ModelTypes['token_bool_exp'] | undefined;
     _or?: Array<ModelTypes['token_bool_exp']> | undefined;
     chain_id?: ModelTypes['String_comparison_exp'] | undefined;
-    circulating_supply?: ModelTypes['bigint_comparison_exp'] | undefined;
+    circulating_supply?: ModelTypes['numeric_comparison_exp'] | undefined;
     content_path?: ModelTypes['String_comparison_exp'] | undefined;
     content_size_bytes?: ModelTypes['Int_comparison_exp'] | undefined;
     creator?: ModelTypes['String_comparison_exp'] | undefined;
@@ -17169,7 +17169,7 @@ export type ModelTypes = {
     metadata?: ModelTypes['String_comparison_exp'] | undefined;
     mint_page?: ModelTypes['String_comparison_exp'] | undefined;
     name?: ModelTypes['String_comparison_exp'] | undefined;
-    per_mint_limit?: ModelTypes['bigint_comparison_exp'] | undefined;
+    per_mint_limit?: ModelTypes['numeric_comparison_exp'] | undefined;
     ticker?: ModelTypes['String_comparison_exp'] | undefined;
     token_address_histories?:
       | ModelTypes['token_address_history_bool_exp']
@@ -17189,7 +17189,7 @@ export type ModelTypes = {
   /** columns and relationships of "token_holder" */
   ['token_holder']: {
     address: string;
-    amount: ModelTypes['bigint'];
+    amount: ModelTypes['numeric'];
     chain_id: string;
     date_updated: ModelTypes['timestamp'];
     id: number;
@@ -17223,7 +17223,7 @@ export type ModelTypes = {
     _not?: ModelTypes['token_holder_bool_exp'] | undefined;
     _or?: Array<ModelTypes['token_holder_bool_exp']> | undefined;
     address?: ModelTypes['String_comparison_exp'] | undefined;
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined;
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined;
     chain_id?: ModelTypes['String_comparison_exp'] | undefined;
     date_updated?: ModelTypes['timestamp_comparison_exp'] | undefined;
     id?: ModelTypes['Int_comparison_exp'] | undefined;
@@ -17287,7 +17287,7 @@ export type ModelTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined;
-    amount?: ModelTypes['bigint'] | undefined;
+    amount?: ModelTypes['numeric'] | undefined;
     chain_id?: string | undefined;
     date_updated?: ModelTypes['timestamp'] | undefined;
     id?: number | undefined;
@@ -17319,7 +17319,7 @@ export type ModelTypes = {
   };
   /** columns and relationships of "token_open_position" */
   ['token_open_position']: {
-    amount: ModelTypes['bigint'];
+    amount: ModelTypes['numeric'];
     chain_id: string;
     date_created: ModelTypes['timestamp'];
     date_filled?: ModelTypes['timestamp'] | undefined;
@@ -17372,7 +17372,7 @@ export type ModelTypes = {
     _and?: Array<ModelTypes['token_open_position_bool_exp']> | undefined;
     _not?: ModelTypes['token_open_position_bool_exp'] | undefined;
     _or?: Array<ModelTypes['token_open_position_bool_exp']> | undefined;
-    amount?: ModelTypes['bigint_comparison_exp'] | undefined;
+    amount?: ModelTypes['numeric_comparison_exp'] | undefined;
     chain_id?: ModelTypes['String_comparison_exp'] | undefined;
     date_created?: ModelTypes['timestamp_comparison_exp'] | undefined;
     date_filled?: ModelTypes['timestamp_comparison_exp'] | undefined;
@@ -17480,7 +17480,7 @@ export type ModelTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: ModelTypes['bigint'] | undefined;
+    amount?: ModelTypes['numeric'] | undefined;
     chain_id?: string | undefined;
     date_created?: ModelTypes['timestamp'] | undefined;
     date_filled?: ModelTypes['timestamp'] | undefined;
@@ -17590,7 +17590,7 @@ export type ModelTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined;
-    circulating_supply?: ModelTypes['bigint'] | undefined;
+    circulating_supply?: ModelTypes['numeric'] | undefined;
     content_path?: string | undefined;
     content_size_bytes?: number | undefined;
     creator?: string | undefined;
@@ -17605,7 +17605,7 @@ export type ModelTypes = {
     metadata?: string | undefined;
     mint_page?: string | undefined;
     name?: string | undefined;
-    per_mint_limit?: ModelTypes['bigint'] | undefined;
+    per_mint_limit?: ModelTypes['numeric'] | undefined;
     ticker?: string | undefined;
     transaction_id?: number | undefined;
     version?: string | undefined;
@@ -17613,7 +17613,7 @@ export type ModelTypes = {
   };
   /** columns and relationships of "token_trade_history" */
   ['token_trade_history']: {
-    amount_base: ModelTypes['bigint'];
+    amount_base: ModelTypes['numeric'];
     amount_quote: ModelTypes['bigint'];
     buyer_address?: string | undefined;
     chain_id: string;
@@ -17662,7 +17662,7 @@ export type ModelTypes = {
     _and?: Array<ModelTypes['token_trade_history_bool_exp']> | undefined;
     _not?: ModelTypes['token_trade_history_bool_exp'] | undefined;
     _or?: Array<ModelTypes['token_trade_history_bool_exp']> | undefined;
-    amount_base?: ModelTypes['bigint_comparison_exp'] | undefined;
+    amount_base?: ModelTypes['numeric_comparison_exp'] | undefined;
     amount_quote?: ModelTypes['bigint_comparison_exp'] | undefined;
     buyer_address?: ModelTypes['String_comparison_exp'] | undefined;
     chain_id?: ModelTypes['String_comparison_exp'] | undefined;
@@ -17760,7 +17760,7 @@ export type ModelTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
-    amount_base?: ModelTypes['bigint'] | undefined;
+    amount_base?: ModelTypes['numeric'] | undefined;
     amount_quote?: ModelTypes['bigint'] | undefined;
     buyer_address?: string | undefined;
     chain_id?: string | undefined;
@@ -18683,7 +18683,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "marketplace_cft20_detail" */
   ['marketplace_cft20_detail']: {
     __typename: 'marketplace_cft20_detail';
-    amount: GraphQLTypes['bigint'];
+    amount: GraphQLTypes['numeric'];
     date_created: GraphQLTypes['timestamp'];
     id: number;
     listing_id: number;
@@ -18733,7 +18733,7 @@ export type GraphQLTypes = {
     _and?: Array<GraphQLTypes['marketplace_cft20_detail_bool_exp']> | undefined;
     _not?: GraphQLTypes['marketplace_cft20_detail_bool_exp'] | undefined;
     _or?: Array<GraphQLTypes['marketplace_cft20_detail_bool_exp']> | undefined;
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined;
     id?: GraphQLTypes['Int_comparison_exp'] | undefined;
     listing_id?: GraphQLTypes['Int_comparison_exp'] | undefined;
@@ -18810,7 +18810,7 @@ export type GraphQLTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_detail_stream_cursor_value_input']: {
-    amount?: GraphQLTypes['bigint'] | undefined;
+    amount?: GraphQLTypes['numeric'] | undefined;
     date_created?: GraphQLTypes['timestamp'] | undefined;
     id?: number | undefined;
     listing_id?: number | undefined;
@@ -18852,7 +18852,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "marketplace_cft20_trade_history" */
   ['marketplace_cft20_trade_history']: {
     __typename: 'marketplace_cft20_trade_history';
-    amount_base: GraphQLTypes['bigint'];
+    amount_base: GraphQLTypes['numeric'];
     amount_quote: GraphQLTypes['bigint'];
     buyer_address?: string | undefined;
     date_created: GraphQLTypes['timestamp'];
@@ -18924,7 +18924,7 @@ export type GraphQLTypes = {
     _or?:
       | Array<GraphQLTypes['marketplace_cft20_trade_history_bool_exp']>
       | undefined;
-    amount_base?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    amount_base?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     amount_quote?: GraphQLTypes['bigint_comparison_exp'] | undefined;
     buyer_address?: GraphQLTypes['String_comparison_exp'] | undefined;
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined;
@@ -19032,7 +19032,7 @@ export type GraphQLTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['marketplace_cft20_trade_history_stream_cursor_value_input']: {
-    amount_base?: GraphQLTypes['bigint'] | undefined;
+    amount_base?: GraphQLTypes['numeric'] | undefined;
     amount_quote?: GraphQLTypes['bigint'] | undefined;
     buyer_address?: string | undefined;
     date_created?: GraphQLTypes['timestamp'] | undefined;
@@ -19992,7 +19992,7 @@ export type GraphQLTypes = {
   ['token']: {
     __typename: 'token';
     chain_id: string;
-    circulating_supply: GraphQLTypes['bigint'];
+    circulating_supply: GraphQLTypes['numeric'];
     content_path?: string | undefined;
     content_size_bytes?: number | undefined;
     creator: string;
@@ -20013,7 +20013,7 @@ export type GraphQLTypes = {
     metadata?: string | undefined;
     mint_page: string;
     name: string;
-    per_mint_limit: GraphQLTypes['bigint'];
+    per_mint_limit: GraphQLTypes['numeric'];
     ticker: string;
     /** An array relationship */
     token_address_histories: Array<GraphQLTypes['token_address_history']>;
@@ -20033,7 +20033,7 @@ export type GraphQLTypes = {
   ['token_address_history']: {
     __typename: 'token_address_history';
     action: string;
-    amount: GraphQLTypes['bigint'];
+    amount: GraphQLTypes['numeric'];
     chain_id: string;
     date_created: GraphQLTypes['timestamp'];
     height: number;
@@ -20085,7 +20085,7 @@ export type GraphQLTypes = {
     _not?: GraphQLTypes['token_address_history_bool_exp'] | undefined;
     _or?: Array<GraphQLTypes['token_address_history_bool_exp']> | undefined;
     action?: GraphQLTypes['String_comparison_exp'] | undefined;
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined;
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined;
     height?: GraphQLTypes['Int_comparison_exp'] | undefined;
@@ -20174,7 +20174,7 @@ export type GraphQLTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_address_history_stream_cursor_value_input']: {
     action?: string | undefined;
-    amount?: GraphQLTypes['bigint'] | undefined;
+    amount?: GraphQLTypes['numeric'] | undefined;
     chain_id?: string | undefined;
     date_created?: GraphQLTypes['timestamp'] | undefined;
     height?: number | undefined;
@@ -20222,7 +20222,7 @@ export type GraphQLTypes = {
     _not?: GraphQLTypes['token_bool_exp'] | undefined;
     _or?: Array<GraphQLTypes['token_bool_exp']> | undefined;
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined;
-    circulating_supply?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    circulating_supply?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     content_path?: GraphQLTypes['String_comparison_exp'] | undefined;
     content_size_bytes?: GraphQLTypes['Int_comparison_exp'] | undefined;
     creator?: GraphQLTypes['String_comparison_exp'] | undefined;
@@ -20243,7 +20243,7 @@ export type GraphQLTypes = {
     metadata?: GraphQLTypes['String_comparison_exp'] | undefined;
     mint_page?: GraphQLTypes['String_comparison_exp'] | undefined;
     name?: GraphQLTypes['String_comparison_exp'] | undefined;
-    per_mint_limit?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    per_mint_limit?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     ticker?: GraphQLTypes['String_comparison_exp'] | undefined;
     token_address_histories?:
       | GraphQLTypes['token_address_history_bool_exp']
@@ -20264,7 +20264,7 @@ export type GraphQLTypes = {
   ['token_holder']: {
     __typename: 'token_holder';
     address: string;
-    amount: GraphQLTypes['bigint'];
+    amount: GraphQLTypes['numeric'];
     chain_id: string;
     date_updated: GraphQLTypes['timestamp'];
     id: number;
@@ -20298,7 +20298,7 @@ export type GraphQLTypes = {
     _not?: GraphQLTypes['token_holder_bool_exp'] | undefined;
     _or?: Array<GraphQLTypes['token_holder_bool_exp']> | undefined;
     address?: GraphQLTypes['String_comparison_exp'] | undefined;
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined;
     date_updated?: GraphQLTypes['timestamp_comparison_exp'] | undefined;
     id?: GraphQLTypes['Int_comparison_exp'] | undefined;
@@ -20363,7 +20363,7 @@ export type GraphQLTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_holder_stream_cursor_value_input']: {
     address?: string | undefined;
-    amount?: GraphQLTypes['bigint'] | undefined;
+    amount?: GraphQLTypes['numeric'] | undefined;
     chain_id?: string | undefined;
     date_updated?: GraphQLTypes['timestamp'] | undefined;
     id?: number | undefined;
@@ -20396,7 +20396,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "token_open_position" */
   ['token_open_position']: {
     __typename: 'token_open_position';
-    amount: GraphQLTypes['bigint'];
+    amount: GraphQLTypes['numeric'];
     chain_id: string;
     date_created: GraphQLTypes['timestamp'];
     date_filled?: GraphQLTypes['timestamp'] | undefined;
@@ -20453,7 +20453,7 @@ export type GraphQLTypes = {
     _and?: Array<GraphQLTypes['token_open_position_bool_exp']> | undefined;
     _not?: GraphQLTypes['token_open_position_bool_exp'] | undefined;
     _or?: Array<GraphQLTypes['token_open_position_bool_exp']> | undefined;
-    amount?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    amount?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined;
     date_created?: GraphQLTypes['timestamp_comparison_exp'] | undefined;
     date_filled?: GraphQLTypes['timestamp_comparison_exp'] | undefined;
@@ -20562,7 +20562,7 @@ export type GraphQLTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_open_position_stream_cursor_value_input']: {
-    amount?: GraphQLTypes['bigint'] | undefined;
+    amount?: GraphQLTypes['numeric'] | undefined;
     chain_id?: string | undefined;
     date_created?: GraphQLTypes['timestamp'] | undefined;
     date_filled?: GraphQLTypes['timestamp'] | undefined;
@@ -20673,7 +20673,7 @@ export type GraphQLTypes = {
   /** Initial value of the column from where the streaming should start */
   ['token_stream_cursor_value_input']: {
     chain_id?: string | undefined;
-    circulating_supply?: GraphQLTypes['bigint'] | undefined;
+    circulating_supply?: GraphQLTypes['numeric'] | undefined;
     content_path?: string | undefined;
     content_size_bytes?: number | undefined;
     creator?: string | undefined;
@@ -20688,7 +20688,7 @@ export type GraphQLTypes = {
     metadata?: string | undefined;
     mint_page?: string | undefined;
     name?: string | undefined;
-    per_mint_limit?: GraphQLTypes['bigint'] | undefined;
+    per_mint_limit?: GraphQLTypes['numeric'] | undefined;
     ticker?: string | undefined;
     transaction_id?: number | undefined;
     version?: string | undefined;
@@ -20697,7 +20697,7 @@ export type GraphQLTypes = {
   /** columns and relationships of "token_trade_history" */
   ['token_trade_history']: {
     __typename: 'token_trade_history';
-    amount_base: GraphQLTypes['bigint'];
+    amount_base: GraphQLTypes['numeric'];
     amount_quote: GraphQLTypes['bigint'];
     buyer_address?: string | undefined;
     chain_id: string;
@@ -20750,7 +20750,7 @@ export type GraphQLTypes = {
     _and?: Array<GraphQLTypes['token_trade_history_bool_exp']> | undefined;
     _not?: GraphQLTypes['token_trade_history_bool_exp'] | undefined;
     _or?: Array<GraphQLTypes['token_trade_history_bool_exp']> | undefined;
-    amount_base?: GraphQLTypes['bigint_comparison_exp'] | undefined;
+    amount_base?: GraphQLTypes['numeric_comparison_exp'] | undefined;
     amount_quote?: GraphQLTypes['bigint_comparison_exp'] | undefined;
     buyer_address?: GraphQLTypes['String_comparison_exp'] | undefined;
     chain_id?: GraphQLTypes['String_comparison_exp'] | undefined;
@@ -20849,7 +20849,7 @@ export type GraphQLTypes = {
   };
   /** Initial value of the column from where the streaming should start */
   ['token_trade_history_stream_cursor_value_input']: {
-    amount_base?: GraphQLTypes['bigint'] | undefined;
+    amount_base?: GraphQLTypes['numeric'] | undefined;
     amount_quote?: GraphQLTypes['bigint'] | undefined;
     buyer_address?: string | undefined;
     chain_id?: string | undefined;
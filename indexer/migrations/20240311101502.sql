@@ -0,0 +1,14 @@
+-- Modify "marketplace_cft20_detail" table
+ALTER TABLE "public"."marketplace_cft20_detail" ALTER COLUMN "amount" TYPE numeric;
+-- Modify "marketplace_cft20_trade_history" table
+ALTER TABLE "public"."marketplace_cft20_trade_history" ALTER COLUMN "amount_base" TYPE numeric;
+-- Modify "token" table
+ALTER TABLE "public"."token" ALTER COLUMN "per_mint_limit" TYPE numeric, ALTER COLUMN "circulating_supply" TYPE numeric;
+-- Modify "token_address_history" table
+ALTER TABLE "public"."token_address_history" ALTER COLUMN "amount" TYPE numeric;
+-- Modify "token_holder" table
+ALTER TABLE "public"."token_holder" ALTER COLUMN "amount" TYPE numeric;
+-- Modify "token_open_position" table
+ALTER TABLE "public"."token_open_position" ALTER COLUMN "amount" TYPE numeric;
+-- Modify "token_trade_history" table
+ALTER TABLE "public"."token_trade_history" ALTER COLUMN "amount_base" TYPE numeric;
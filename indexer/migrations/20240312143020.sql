@@ -0,0 +1,2 @@
+-- Modify "token" table
+ALTER TABLE "public"."token" ADD COLUMN "is_frozen" boolean NOT NULL DEFAULT false;
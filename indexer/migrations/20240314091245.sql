@@ -0,0 +1,11 @@
+-- Create "block" table
+CREATE TABLE "public"."block" (
+  "id" serial NOT NULL,
+  "chain_id" character varying(32) NOT NULL,
+  "height" integer NOT NULL,
+  "hash" character varying(128) NOT NULL,
+  "date_created" timestamp NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "block_chain_height_key" to table: "block"
+CREATE UNIQUE INDEX "block_chain_height_key" ON "public"."block" ("chain_id", "height");
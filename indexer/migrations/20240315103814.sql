@@ -0,0 +1,2 @@
+-- Modify "token" table
+ALTER TABLE "public"."token" ADD COLUMN "remaining_supply" numeric NOT NULL GENERATED ALWAYS AS (max_supply - circulating_supply) STORED;
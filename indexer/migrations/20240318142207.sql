@@ -0,0 +1,2 @@
+-- Modify "token" table
+ALTER TABLE "public"."token" ADD COLUMN "date_deleted" timestamp NULL;
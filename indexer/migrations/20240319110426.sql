@@ -0,0 +1,2 @@
+-- Modify "token" table
+ALTER TABLE "public"."token" ADD COLUMN "amount_precision" character varying(16) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Modify "inscription" table
+ALTER TABLE "public"."inscription" ADD COLUMN "collection_id" integer NULL, ADD CONSTRAINT "inscription_collection_fk" FOREIGN KEY ("collection_id") REFERENCES "public"."inscription" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION;
+-- Create index "idx_inscription_collection_id" to table: "inscription"
+CREATE INDEX "idx_inscription_collection_id" ON "public"."inscription" ("collection_id");
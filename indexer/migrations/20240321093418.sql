@@ -0,0 +1,2 @@
+-- Create index "idx_token_holder_token_amount" to table: "token_holder"
+CREATE INDEX "idx_token_holder_token_amount" ON "public"."token_holder" ("token_id", "amount");
@@ -0,0 +1,2 @@
+-- Create "token_address_balance_history" view
+CREATE VIEW "public"."token_address_balance_history" AS SELECT "chain_id", "token_id", "address", "height", SUM(SUM("delta")) OVER (PARTITION BY "chain_id", "token_id", "address" ORDER BY "height") AS "balance" FROM (SELECT "chain_id", "token_id", "sender" AS "address", "height", -"amount" AS "delta" FROM "public"."token_address_history" WHERE "action" <> 'sell' UNION ALL SELECT "chain_id", "token_id", "receiver" AS "address", "height", "amount" AS "delta" FROM "public"."token_address_history" WHERE "action" <> 'sell' AND "receiver" IS NOT NULL) AS "events" GROUP BY "chain_id", "token_id", "address", "height";
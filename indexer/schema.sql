@@ -38,6 +38,23 @@ CREATE TABLE public."transaction" (
 CREATE INDEX idx_tx_hash ON public.transaction USING btree (hash);
 
 
+-- public.block definition
+
+-- Drop table
+
+-- DROP TABLE public.block;
+
+CREATE TABLE public.block (
+    id serial4 NOT NULL,
+    chain_id varchar(32) NOT NULL,
+    height int4 NOT NULL,
+    hash varchar(128) NOT NULL,
+    date_created timestamp NOT NULL,
+    CONSTRAINT block_pkey PRIMARY KEY (id),
+    CONSTRAINT block_chain_height_key UNIQUE (chain_id, height)
+);
+
+
 -- public.inscription definition
 
 -- Drop table
@@ -59,11 +76,14 @@ CREATE TABLE public.inscription (
     content_size_bytes int4 NOT NULL,
     date_created timestamp NOT NULL,
     is_explicit bool NULL DEFAULT false,
+    collection_id int4 NULL,
     CONSTRAINT inscription_content_hash_key UNIQUE (content_hash),
     CONSTRAINT inscription_pkey PRIMARY KEY (id),
     CONSTRAINT inscription_tx_id UNIQUE (transaction_id),
-    CONSTRAINT inscription_transaction_fk FOREIGN KEY (transaction_id) REFERENCES public."transaction"(id)
+    CONSTRAINT inscription_transaction_fk FOREIGN KEY (transaction_id) REFERENCES public."transaction"(id),
+    CONSTRAINT inscription_collection_fk FOREIGN KEY (collection_id) REFERENCES public.inscription(id)
 );
+CREATE INDEX "idx_inscription_collection_id" ON "public"."inscription" USING btree ("collection_id");
 CREATE INDEX idx_inscriptions_owner_date ON public.inscription USING btree (date_created);
 CREATE INDEX "idx_inscription_current_owner" ON "public"."inscription" USING btree ("current_owner");
 
@@ -157,17 +177,21 @@ CREATE TABLE public."token" (
     ticker varchar(10) NOT NULL,
     decimals int2 NOT NULL,
     max_supply numeric NOT NULL,
-    per_mint_limit int8 NOT NULL,
+    per_mint_limit numeric NOT NULL,
     launch_timestamp int8 NOT NULL,
     mint_page varchar(128) NOT NULL DEFAULT 'default'::character varying,
     metadata text NULL,
     content_path varchar(255) NULL DEFAULT NULL::character varying,
     content_size_bytes int4 NULL,
-    circulating_supply int8 NOT NULL DEFAULT 0,
+    circulating_supply numeric NOT NULL DEFAULT 0,
     last_price_base int8 NOT NULL DEFAULT 0,
     volume_24_base int8 NOT NULL DEFAULT 0,
     date_created timestamp NOT NULL,
     is_explicit bool NULL DEFAULT false,
+    is_frozen bool NOT NULL DEFAULT false,
+    amount_precision varchar(16) NOT NULL DEFAULT '',
+    remaining_supply numeric NOT NULL GENERATED ALWAYS AS (max_supply - circulating_supply) STORED,
+    date_deleted timestamp NULL,
     CONSTRAINT token_pkey PRIMARY KEY (id),
     CONSTRAINT token_ticker_key UNIQUE (ticker),
     CONSTRAINT token_tx_id UNIQUE (transaction_id),
@@ -190,7 +214,7 @@ CREATE TABLE public.token_address_history (
     token_id int4 NOT NULL,
     sender varchar(128) NOT NULL,
     "action" varchar(32) NOT NULL,
-    amount int8 NOT NULL,
+    amount numeric NOT NULL,
     receiver varchar(128) NULL,
     date_created timestamp NOT NULL,
     CONSTRAINT token_address_history_pkey PRIMARY KEY (id),
@@ -202,6 +226,36 @@ CREATE INDEX "idx_token_address_history_receiver" ON "public"."token_address_his
 CREATE INDEX "idx_token_address_history_sender" ON "public"."token_address_history" USING btree ("sender");
 
 
+-- public.token_address_balance_history definition
+
+-- Balance of each address after the history events at every height. A sale is
+-- recorded for the seller's information, the tokens left the seller's balance
+-- when they were listed, so it is left out. Mints are sent by the token's
+-- ticker and listed tokens are held by the marketplace escrow addresses,
+-- neither is an address with a balance
+
+-- DROP VIEW public.token_address_balance_history;
+
+CREATE VIEW public.token_address_balance_history AS
+SELECT
+    chain_id,
+    token_id,
+    address,
+    height,
+    SUM(SUM(delta)) OVER (PARTITION BY chain_id, token_id, address ORDER BY height) AS balance
+FROM (
+    SELECT chain_id, token_id, sender AS address, height, -amount AS delta
+    FROM public.token_address_history
+    WHERE "action" NOT IN ('mint', 'sell')
+    UNION ALL
+    SELECT chain_id, token_id, receiver AS address, height, amount AS delta
+    FROM public.token_address_history
+    WHERE "action" <> 'sell' AND receiver IS NOT NULL
+) AS events
+WHERE address NOT IN ('marketplace', 'marketplace-v2')
+GROUP BY chain_id, token_id, address, height;
+
+
 -- public.token_holder definition
 
 -- Drop table
@@ -213,13 +267,14 @@ CREATE TABLE public.token_holder (
     chain_id varchar(32) NOT NULL,
     token_id int4 NOT NULL,
     address varchar(128) NOT NULL,
-    amount int8 NOT NULL,
+    amount numeric NOT NULL,
     date_updated timestamp NOT NULL,
     CONSTRAINT token_holder_pkey PRIMARY KEY (id),
     CONSTRAINT token_id_fk FOREIGN KEY (token_id) REFERENCES public."token"(id)
 );
 CREATE INDEX token_holder_ticker_idx ON public.token_holder USING btree (token_id);
 CREATE INDEX "idx_token_holder_address" ON "public"."token_holder" USING btree ("address");
+CREATE INDEX "idx_token_holder_token_amount" ON "public"."token_holder" USING btree ("token_id", "amount");
 
 
 -- public.token_open_position definition
@@ -234,7 +289,7 @@ CREATE TABLE public.token_open_position (
     transaction_id int4 NOT NULL,
     token_id int4 NOT NULL,
     seller_address varchar(128) NOT NULL,
-    amount int8 NOT NULL,
+    amount numeric NOT NULL,
     ppt int8 NOT NULL,
     total int8 NOT NULL,
     is_filled bool NOT NULL DEFAULT false,
@@ -260,7 +315,7 @@ CREATE TABLE public.token_trade_history (
     token_id int4 NOT NULL,
     seller_address varchar(128) NOT NULL,
     buyer_address varchar(128) NULL,
-    amount_base int8 NOT NULL,
+    amount_base numeric NOT NULL,
     amount_quote int8 NOT NULL,
     rate int4 NOT NULL DEFAULT 0,
     total_usd float4 NOT NULL DEFAULT 0,
@@ -320,7 +375,7 @@ CREATE TABLE public.marketplace_cft20_detail (
     id serial4 NOT NULL,
     listing_id int4 NOT NULL,
     token_id int4 NOT NULL,
-    amount int8 NOT NULL,
+    amount numeric NOT NULL,
     ppt int8 NOT NULL,
     date_created timestamp NOT NULL,
     CONSTRAINT marketplace_cft20_detail_pkey PRIMARY KEY (id),
@@ -343,7 +398,7 @@ CREATE TABLE public.marketplace_cft20_trade_history (
     seller_address varchar(128) NOT NULL,
     buyer_address varchar(128) NULL,
     amount_quote int8 NOT NULL,
-    amount_base int8 NOT NULL,
+    amount_base numeric NOT NULL,
     rate int4 NOT NULL DEFAULT 0,
     total_usd float4 NOT NULL DEFAULT 0,
     date_created timestamp NOT NULL,
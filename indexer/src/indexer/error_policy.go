@@ -0,0 +1,74 @@
+package indexer
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/metaprotocol"
+)
+
+// ErrorAction decides what the indexer does when a metaprotocol processor
+// returns an error for a transaction
+type ErrorAction int
+
+const (
+	// ErrorActionSkip marks the transaction as failed and continues indexing
+	ErrorActionSkip ErrorAction = iota
+	// ErrorActionRetry processes the transaction again, up to
+	// maxProcessRetries times before halting
+	ErrorActionRetry
+	// ErrorActionHalt stops the indexer without marking the block processed
+	ErrorActionHalt
+)
+
+// maxProcessRetries is the number of times a transaction is retried when the
+// error policy returns ErrorActionRetry
+const maxProcessRetries = 3
+
+// ErrorPolicy classifies an error returned from a processor
+//
+// Processors store all of an operation's changes or none of them, so a
+// transaction that is retried starts from the same state as the first attempt
+type ErrorPolicy func(err error) ErrorAction
+
+// DefaultErrorPolicy skips invalid operations, which are expected to fail,
+// and retries transactions that failed because the database connection was
+// lost or a balance was changed by another writer. Any other error halts the
+// indexer, skipping a transaction that failed for an unknown reason would
+// leave its changes out of the index for good
+func DefaultErrorPolicy(err error) ErrorAction {
+	if errors.Is(err, metaprotocol.ErrInvalidOperation) {
+		return ErrorActionSkip
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, metaprotocol.ErrBalanceChanged) {
+		return ErrorActionRetry
+	}
+	return ErrorActionHalt
+}
+
+// SetErrorPolicy replaces the policy used to handle processing errors
+func (i *Indexer) SetErrorPolicy(policy ErrorPolicy) {
+	i.errorPolicy = policy
+}
+
+// processWithPolicy runs process and applies the error policy to the result.
+// It returns the error if the transaction should be skipped or the indexer
+// halted
+func (i *Indexer) processWithPolicy(process func() error) (ErrorAction, error) {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = process()
+		if err == nil {
+			return ErrorActionSkip, nil
+		}
+		action := i.errorPolicy(err)
+		if action == ErrorActionRetry && attempt < maxProcessRetries {
+			i.logger.WithField("attempt", attempt+1).Warn("Retrying transaction: ", err)
+			continue
+		}
+		if action == ErrorActionRetry {
+			return ErrorActionHalt, err
+		}
+		return action, err
+	}
+}
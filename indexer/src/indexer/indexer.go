@@ -2,6 +2,7 @@ package indexer
 
 import (
 	"crypto/sha256"
+	"database/sql"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -23,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -34,6 +36,17 @@ type Config struct {
 	RPCEndpoints             []string          `envconfig:"RPC_ENDPOINTS" required:"true"`
 	EndpointHeaders          map[string]string `envconfig:"ENDPOINT_HEADERS" required:"true"`
 	BlockPollIntervalMS      int               `envconfig:"BLOCK_POLL_INTERVAL_MS" required:"true"`
+	FinalityDepth            uint64            `envconfig:"FINALITY_DEPTH" default:"1000"`
+	// URNSources are the places a metaprotocol URN is looked for. Only one
+	// URN may be found across all of them
+	URNSources []string `envconfig:"URN_SOURCES" default:"memo"`
+	// Database connection pool settings, the defaults match database/sql
+	DatabaseMaxOpenConns    int           `envconfig:"DATABASE_MAX_OPEN_CONNS" default:"0"`
+	DatabaseMaxIdleConns    int           `envconfig:"DATABASE_MAX_IDLE_CONNS" default:"2"`
+	DatabaseConnMaxLifetime time.Duration `envconfig:"DATABASE_CONN_MAX_LIFETIME" default:"0s"`
+	// VerifySigner rejects transactions whose sender doesn't match the signer,
+	// for when the LCD endpoints can't be fully trusted
+	VerifySigner bool `envconfig:"VERIFY_SIGNER" default:"false"`
 }
 
 // Indexer implements the reference indexer service
@@ -44,8 +57,14 @@ type Indexer struct {
 	rpcEndpoints             []string
 	endpointHeaders          map[string]string
 	blockPollIntervalMS      int
+	finalityDepth            uint64
+	urnSources               []string
+	verifySigner             bool
 	logger                   *logrus.Entry
 	metaprotocols            map[string]metaprotocol.Processor
+	cft20                    *metaprotocol.CFT20
+	errorPolicy              ErrorPolicy
+	phaseTimer               PhaseTimer
 	stopChannel              chan bool
 	db                       *gorm.DB
 	wg                       sync.WaitGroup
@@ -62,6 +81,11 @@ func New(
 	if err != nil {
 		log.Fatalf("Unable to process config: %s", err)
 	}
+	for _, source := range config.URNSources {
+		if source != types.URNSourceMemo && source != types.URNSourceMessageMemo {
+			log.Fatalf("Unable to process config: unknown URN source '%s'", source)
+		}
+	}
 
 	db, err := gorm.Open(postgres.Open(config.DatabaseDSN), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
@@ -70,10 +94,16 @@ func New(
 		return nil, err
 
 	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	configurePool(sqlDB, config)
 
 	metaprotocols := make(map[string]metaprotocol.Processor)
 	metaprotocols["inscription"] = metaprotocol.NewInscriptionProcessor(config.ChainID, db)
-	metaprotocols["cft20"] = metaprotocol.NewCFT20Processor(config.ChainID, db)
+	cft20 := metaprotocol.NewCFT20Processor(config.ChainID, db)
+	metaprotocols["cft20"] = cft20
 	metaprotocols["marketplace"] = metaprotocol.NewMarketplaceProcessor(config.ChainID, db)
 
 	return &Indexer{
@@ -83,7 +113,12 @@ func New(
 		rpcEndpoints:             config.RPCEndpoints,
 		endpointHeaders:          config.EndpointHeaders,
 		blockPollIntervalMS:      config.BlockPollIntervalMS,
+		finalityDepth:            config.FinalityDepth,
+		urnSources:               config.URNSources,
+		verifySigner:             config.VerifySigner,
 		metaprotocols:            metaprotocols,
+		cft20:                    cft20,
+		errorPolicy:              DefaultErrorPolicy,
 		logger:                   log,
 		stopChannel:              make(chan bool),
 		db:                       db,
@@ -93,12 +128,22 @@ func New(
 // Run the indexer service forever
 func (i *Indexer) Run() error {
 	i.logger.Info("Starting indexer")
+	for id, processor := range i.metaprotocols {
+		i.logger.WithFields(logrus.Fields{
+			"metaprotocol": id,
+			"processor":    processor.Name(),
+			"version":      processor.Version(),
+		}).Info("Loaded metaprotocol processor")
+	}
 	i.wg.Add(1)
 	go i.indexBlocks()
 
 	i.wg.Add(1)
 	go i.updateBaseToken()
 
+	i.wg.Add(1)
+	go i.reportPoolStats()
+
 	i.wg.Wait()
 
 	return nil
@@ -109,9 +154,22 @@ func (i *Indexer) Stop() error {
 	i.logger.Info("Stopping indexer")
 	i.stopChannel <- true
 	i.stopChannel <- true
+	i.stopChannel <- true
 	return nil
 }
 
+// SetBalanceObserver sets the observer notified of token balance changes on
+// every processor that changes balances
+func (i *Indexer) SetBalanceObserver(observer metaprotocol.BalanceObserver) {
+	for _, processor := range i.metaprotocols {
+		if balanceProcessor, ok := processor.(interface {
+			SetBalanceObserver(metaprotocol.BalanceObserver)
+		}); ok {
+			balanceProcessor.SetBalanceObserver(observer)
+		}
+	}
+}
+
 // indexBlocks fetches blocks from the chain, indexes them and stores a
 // record of the last processed block
 func (i *Indexer) indexBlocks() {
@@ -145,12 +203,19 @@ func (i *Indexer) indexBlocks() {
 	ticker := time.NewTicker(time.Duration(i.blockPollIntervalMS) * time.Millisecond)
 	defer ticker.Stop()
 
+	// Pruning deletes rows the processors read and write, so it runs in
+	// this loop between blocks rather than concurrently with them
+	pruneTicker := time.NewTicker(1 * time.Hour)
+	defer pruneTicker.Stop()
+
 	for {
 		select {
 		case <-i.stopChannel:
 			i.logger.Info("Stop fetching data")
 			i.wg.Done()
 			return
+		case <-pruneTicker.C:
+			i.prune()
 		case <-ticker.C:
 
 			maxHeight, err := i.fetchCurrentHeight()
@@ -182,6 +247,16 @@ func (i *Indexer) indexBlocks() {
 				i.logger.Fatal(err)
 			}
 
+			// Make sure this block builds on the block we processed before
+			// it. If it doesn't, the chain reorganised and continuing would
+			// apply transactions on top of state from an orphaned block
+			err = i.verifyParentHash(height, block)
+			if err != nil {
+				i.logger.WithFields(logrus.Fields{
+					"height": height,
+				}).Fatal(err)
+			}
+
 			for _, tx := range transactions {
 				gasUsed, err := strconv.ParseUint(tx.AuthInfo.Fee.GasLimit, 10, 64)
 				if err != nil {
@@ -219,7 +294,14 @@ func (i *Indexer) indexBlocks() {
 
 				// Process metaprotocol memo
 				statusMessage := types.TransactionStateSuccess
-				err = i.processMetaprotocolMemo(txModel, tx)
+				action, err := i.processWithPolicy(func() error {
+					return i.processMetaprotocolMemo(txModel, tx)
+				})
+				if action == ErrorActionHalt {
+					i.logger.WithFields(logrus.Fields{
+						"hash": tx.Hash,
+					}).Fatal(err)
+				}
 				if err != nil {
 					i.logger.WithFields(logrus.Fields{
 						"hash": tx.Hash,
@@ -243,6 +325,15 @@ func (i *Indexer) indexBlocks() {
 				}).Info("Transaction processed")
 			}
 
+			// Store the block hash so the next block's parent can be verified
+			err = i.saveBlock(height, block)
+			if err != nil {
+				i.logger.WithFields(logrus.Fields{
+					"height": height,
+					"err":    err,
+				}).Fatal("Unable to store block")
+			}
+
 			i.logger.WithFields(logrus.Fields{
 				"height": height,
 			}).Info("Block processed")
@@ -309,29 +400,192 @@ func (i *Indexer) updateBaseToken() {
 	}
 }
 
+// configurePool applies the connection pool settings from config to db
+func configurePool(db *sql.DB, config Config) {
+	db.SetMaxOpenConns(config.DatabaseMaxOpenConns)
+	db.SetMaxIdleConns(config.DatabaseMaxIdleConns)
+	db.SetConnMaxLifetime(config.DatabaseConnMaxLifetime)
+}
+
+// reportPoolStats logs the database connection pool statistics every minute
+func (i *Indexer) reportPoolStats() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-i.stopChannel:
+			i.logger.Info("Stop reporting pool stats")
+			i.wg.Done()
+			return
+		case <-ticker.C:
+			sqlDB, err := i.db.DB()
+			if err != nil {
+				i.logger.Error(err)
+				continue
+			}
+			stats := sqlDB.Stats()
+			i.logger.WithFields(logrus.Fields{
+				"open":          stats.OpenConnections,
+				"in_use":        stats.InUse,
+				"idle":          stats.Idle,
+				"wait_count":    stats.WaitCount,
+				"wait_duration": stats.WaitDuration,
+			}).Info("Database pool stats")
+		}
+	}
+}
+
+// prune removes token holders without a balance and block hashes that are
+// no longer needed. It must not run while a block is being processed
+func (i *Indexer) prune() {
+	pruned, err := i.cft20.PruneZeroHolders(i.chainID)
+	if err != nil {
+		i.logger.Error(err)
+		return
+	}
+	i.logger.WithFields(logrus.Fields{
+		"pruned": pruned,
+	}).Info("Pruned zero balance token holders")
+
+	pruned, err = i.pruneBlocks()
+	if err != nil {
+		i.logger.Error(err)
+		return
+	}
+	i.logger.WithFields(logrus.Fields{
+		"pruned": pruned,
+	}).Info("Pruned finalised block hashes")
+}
+
+// pruneBlocks removes stored block hashes that are deeper than the finality
+// depth. Reorgs can't reach those blocks, so their hashes are never needed
+// to verify a parent again
+func (i *Indexer) pruneBlocks() (int, error) {
+	var status models.Status
+	result := i.db.Where("chain_id = ?", i.chainID).First(&status)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, result.Error
+	}
+
+	cutoff, ok := blockRetentionCutoff(uint64(status.LastProcessedHeight), i.finalityDepth)
+	if !ok {
+		return 0, nil
+	}
+	result = i.db.Where("chain_id = ? AND height < ?", i.chainID, cutoff).Delete(&models.Block{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// blockRetentionCutoff returns the lowest block height that must be kept.
+// The previous height is always kept since the next block is verified
+// against it. It returns false if nothing can be pruned yet
+func blockRetentionCutoff(lastProcessedHeight uint64, finalityDepth uint64) (uint64, bool) {
+	if finalityDepth == 0 {
+		finalityDepth = 1
+	}
+	if lastProcessedHeight <= finalityDepth {
+		return 0, false
+	}
+	return lastProcessedHeight - finalityDepth, true
+}
+
+// verifyParentHash checks that the parent hash of block matches the hash we
+// stored for the previous height. If we have no record of the previous
+// height, there is nothing to verify against
+func (i *Indexer) verifyParentHash(height uint64, block types.LCDBlock) error {
+	var previousBlock models.Block
+	result := i.db.Where("chain_id = ? AND height = ?", i.chainID, height-1).First(&previousBlock)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return result.Error
+	}
+	return checkParentHash(previousBlock, block)
+}
+
+// saveBlock stores the hash of block at height. A height that is processed
+// again after a reorg replaces the stale hash, otherwise the next block would
+// be verified against a block that is no longer part of the chain
+func (i *Indexer) saveBlock(height uint64, block types.LCDBlock) error {
+	blockModel := models.Block{
+		ChainID:     i.chainID,
+		Height:      height,
+		Hash:        block.BlockID.Hash,
+		DateCreated: block.Block.Header.Time,
+	}
+	result := i.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "height"}},
+		DoUpdates: clause.AssignmentColumns([]string{"hash", "date_created"}),
+	}).Create(&blockModel)
+	return result.Error
+}
+
+// checkParentHash returns an error if block is not the child of previousBlock
+func checkParentHash(previousBlock models.Block, block types.LCDBlock) error {
+	if block.Block.Header.LastBlockID.Hash != previousBlock.Hash {
+		return fmt.Errorf(
+			"reorg detected at height %d: parent hash %s does not match stored hash %s",
+			previousBlock.Height+1,
+			block.Block.Header.LastBlockID.Hash,
+			previousBlock.Hash,
+		)
+	}
+	return nil
+}
+
 // processMetaprotocolMemo handles the processing of different metaprotocols
+//
+// A transaction may hold only one metaprotocol URN across the configured
+// sources, transactions with more are rejected instead of applying one of
+// them. Each transaction therefore applies a single operation. Transactions
+// are processed in the order they appear in the block, which keeps reindexing
+// deterministic
 func (i *Indexer) processMetaprotocolMemo(transactionModel models.Transaction, rawTransaction types.RawTransaction) error {
 	i.logger.WithFields(logrus.Fields{
 		"hash": rawTransaction.Hash,
 	}).Debug("Processing memo")
 
-	metaprotocolURN, ok := urn.Parse([]byte(rawTransaction.Body.Memo))
+	if i.verifySigner {
+		err := rawTransaction.VerifySigner()
+		if err != nil {
+			return metaprotocol.InvalidOperation(fmt.Errorf("invalid signer '%s'", err))
+		}
+	}
+
+	parseDone := i.startPhase("parse")
+	memo, err := rawTransaction.GetMetaprotocolURN(i.urnSources)
+	if err != nil {
+		parseDone()
+		return metaprotocol.InvalidOperation(err)
+	}
+	metaprotocolURN, ok := urn.Parse([]byte(memo))
+	parseDone()
 	if !ok {
-		return errors.New("invalid metaprotocol URN")
+		return metaprotocol.InvalidOperation(errors.New("invalid metaprotocol URN"))
 	}
 
 	// Match the ID and send the SS to the correct processor with base64 data to decode
 	processor, ok := i.metaprotocols[metaprotocolURN.ID]
 	if !ok {
-		return fmt.Errorf("no processor for metaprotocol '%s'", metaprotocolURN.ID)
+		return metaprotocol.InvalidOperation(fmt.Errorf("no processor for metaprotocol '%s'", metaprotocolURN.ID))
 	}
 
 	i.logger.WithFields(logrus.Fields{
 		"processor": processor.Name(),
+		"version":   processor.Version(),
 		"hash":      rawTransaction.Hash,
 	}).Info("Processing metaprotocol")
 
-	err := processor.Process(transactionModel, metaprotocolURN, rawTransaction)
+	processDone := i.startPhase("process:" + metaprotocolURN.ID)
+	err = processor.Process(transactionModel, metaprotocolURN, rawTransaction)
+	processDone()
 	if err != nil {
 		i.logger.WithFields(logrus.Fields{
 			"metaprotocol": metaprotocolURN.ID,
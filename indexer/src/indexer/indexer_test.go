@@ -0,0 +1,308 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/internal/dbtest"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/metaprotocol"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/types"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func TestCheckParentHash(t *testing.T) {
+	previousBlock := models.Block{
+		Height: 100,
+		Hash:   "A/rN0K3gTQIn4KRNqmnXwBkpfHu1xRuvXe6zYOwZGWI=",
+	}
+
+	var block types.LCDBlock
+	block.Block.Header.LastBlockID.Hash = previousBlock.Hash
+	if err := checkParentHash(previousBlock, block); err != nil {
+		t.Errorf("expected matching parent hash to pass, got %v", err)
+	}
+
+	block.Block.Header.LastBlockID.Hash = "kUmeZ0BPsRLZ+ZO7PE2OQVmDNhpdTQ8Ukr5ezeMxwNU="
+	if err := checkParentHash(previousBlock, block); err == nil {
+		t.Errorf("expected mismatched parent hash to fail")
+	}
+}
+
+func TestVerifyParentHash(t *testing.T) {
+	db := dbtest.Open(t)
+	indexer := &Indexer{db: db, chainID: "cosmoshub-4"}
+	dbtest.StubQueries(t, db)
+
+	// Without a stored parent there is nothing to verify against
+	var block types.LCDBlock
+	block.Block.Header.LastBlockID.Hash = "A/rN0K3gTQIn4KRNqmnXwBkpfHu1xRuvXe6zYOwZGWI="
+	if err := indexer.verifyParentHash(101, block); err != nil {
+		t.Errorf("expected block without stored parent to pass, got %v", err)
+	}
+
+	dbtest.StubQueries(t, db, &models.Block{
+		ChainID: "cosmoshub-4",
+		Height:  100,
+		Hash:    "A/rN0K3gTQIn4KRNqmnXwBkpfHu1xRuvXe6zYOwZGWI=",
+	})
+	if err := indexer.verifyParentHash(101, block); err != nil {
+		t.Errorf("expected block on the stored chain to pass, got %v", err)
+	}
+
+	block.Block.Header.LastBlockID.Hash = "kUmeZ0BPsRLZ+ZO7PE2OQVmDNhpdTQ8Ukr5ezeMxwNU="
+	if err := indexer.verifyParentHash(101, block); err == nil {
+		t.Errorf("expected block on a forked chain to fail")
+	}
+}
+
+func TestSaveBlockReplacesHash(t *testing.T) {
+	db := dbtest.Open(t)
+	indexer := &Indexer{db: db, chainID: "cosmoshub-4"}
+
+	var statement string
+	err := db.Callback().Create().After("gorm:create").Register("test:statement", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	})
+	if err != nil {
+		t.Fatalf("unable to register callback: %v", err)
+	}
+
+	var block types.LCDBlock
+	block.BlockID.Hash = "kUmeZ0BPsRLZ+ZO7PE2OQVmDNhpdTQ8Ukr5ezeMxwNU="
+	if err := indexer.saveBlock(100, block); err != nil {
+		t.Fatalf("unable to save block: %v", err)
+	}
+
+	// A reprocessed height must overwrite the hash instead of keeping the old one
+	expected := "ON CONFLICT (chain_id,height) DO UPDATE SET hash=excluded.hash,date_created=excluded.date_created"
+	if !strings.Contains(statement, expected) {
+		t.Errorf("expected block insert to contain '%s', got '%s'", expected, statement)
+	}
+}
+
+func TestDefaultErrorPolicy(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected ErrorAction
+	}{
+		{metaprotocol.InvalidOperation(errors.New("token with ticker 'TEST' is frozen")), ErrorActionSkip},
+		{fmt.Errorf("unable to parse amount '%w'", metaprotocol.ErrInvalidOperation), ErrorActionSkip},
+		{driver.ErrBadConn, ErrorActionRetry},
+		{fmt.Errorf("unable to fetch token: %w", driver.ErrBadConn), ErrorActionRetry},
+		{fmt.Errorf("unable to update receiver balance '%w'", metaprotocol.ErrBalanceChanged), ErrorActionRetry},
+		// Unknown errors, such as a failing query, must not be skipped
+		{errors.New("pq: relation \"token\" does not exist"), ErrorActionHalt},
+		{fmt.Errorf("unable to store content '%w'", errors.New("failed to upload file")), ErrorActionHalt},
+	}
+
+	for _, test := range tests {
+		action := DefaultErrorPolicy(test.err)
+		if action != test.expected {
+			t.Errorf("expected '%s' to map to %d, got %d", test.err, test.expected, action)
+		}
+	}
+}
+
+func TestProcessWithPolicy(t *testing.T) {
+	indexer := &Indexer{
+		logger:      logrus.NewEntry(logrus.New()),
+		errorPolicy: DefaultErrorPolicy,
+	}
+
+	// A connection error is retried until it succeeds
+	calls := 0
+	action, err := indexer.processWithPolicy(func() error {
+		calls++
+		if calls < 2 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("expected success after 2 calls, got %v after %d calls", err, calls)
+	}
+
+	// Retries are bounded and the indexer then halts
+	calls = 0
+	action, err = indexer.processWithPolicy(func() error {
+		calls++
+		return driver.ErrBadConn
+	})
+	if err == nil || action != ErrorActionHalt || calls != maxProcessRetries+1 {
+		t.Errorf("expected halt after %d calls, got %d after %d calls", maxProcessRetries+1, action, calls)
+	}
+
+	// Invalid operations are skipped without a retry
+	calls = 0
+	action, err = indexer.processWithPolicy(func() error {
+		calls++
+		return metaprotocol.InvalidOperation(errors.New("invalid operation"))
+	})
+	if err == nil || action != ErrorActionSkip || calls != 1 {
+		t.Errorf("expected skip after 1 call, got %d after %d calls", action, calls)
+	}
+
+	// A custom policy can halt on any error
+	indexer.SetErrorPolicy(func(err error) ErrorAction {
+		return ErrorActionHalt
+	})
+	calls = 0
+	action, _ = indexer.processWithPolicy(func() error {
+		calls++
+		return errors.New("invalid operation")
+	})
+	if action != ErrorActionHalt || calls != 1 {
+		t.Errorf("expected halt after 1 call, got %d after %d calls", action, calls)
+	}
+}
+
+func TestBlockRetentionCutoff(t *testing.T) {
+	tests := []struct {
+		lastProcessedHeight uint64
+		finalityDepth       uint64
+		expected            uint64
+		prune               bool
+	}{
+		{500, 1000, 0, false},
+		{1000, 1000, 0, false},
+		{1500, 1000, 500, true},
+		{1500, 0, 1499, true},
+	}
+
+	for _, test := range tests {
+		cutoff, prune := blockRetentionCutoff(test.lastProcessedHeight, test.finalityDepth)
+		if prune != test.prune || cutoff != test.expected {
+			t.Errorf("expected height %d with depth %d to give (%d, %t), got (%d, %t)",
+				test.lastProcessedHeight, test.finalityDepth, test.expected, test.prune, cutoff, prune)
+			continue
+		}
+		// The last processed block is always kept to verify the next block
+		if prune && cutoff >= test.lastProcessedHeight {
+			t.Errorf("expected last processed height %d to be kept, cutoff %d", test.lastProcessedHeight, cutoff)
+		}
+	}
+}
+
+type recordingPhaseTimer struct {
+	phases []string
+}
+
+func (timer *recordingPhaseTimer) OnPhase(phase string, duration time.Duration) {
+	timer.phases = append(timer.phases, phase)
+}
+
+func TestPhaseTimer(t *testing.T) {
+	db := dbtest.Open(t)
+	indexer := &Indexer{db: db}
+
+	// Disabled timing records nothing
+	indexer.startPhase("parse")()
+
+	timer := &recordingPhaseTimer{}
+	if err := indexer.SetPhaseTimer(timer); err != nil {
+		t.Fatalf("unable to set phase timer: %v", err)
+	}
+	indexer.startPhase("parse")()
+	var token models.Token
+	db.Where("ticker = ?", "TEST").First(&token)
+	db.Save(&models.TokenHolder{ID: 1, Amount: models.NewAmount(10)})
+
+	expected := []string{"parse", "query:token", "update:token_holder"}
+	if len(timer.phases) != len(expected) {
+		t.Fatalf("expected phases %v, got %v", expected, timer.phases)
+	}
+	for i, phase := range expected {
+		if timer.phases[i] != phase {
+			t.Errorf("expected phase %d to be '%s', got '%s'", i, phase, timer.phases[i])
+		}
+	}
+}
+
+func TestSetPhaseTimerTwice(t *testing.T) {
+	db := dbtest.Open(t)
+	indexer := &Indexer{db: db}
+
+	if err := indexer.SetPhaseTimer(nil); err == nil {
+		t.Errorf("expected a nil timer to be rejected")
+	}
+	// A rejected timer leaves timing disabled
+	var token models.Token
+	db.Where("ticker = ?", "TEST").First(&token)
+
+	first := &recordingPhaseTimer{}
+	second := &recordingPhaseTimer{}
+	if err := indexer.SetPhaseTimer(first); err != nil {
+		t.Fatalf("unable to set phase timer: %v", err)
+	}
+	if err := indexer.SetPhaseTimer(second); err != nil {
+		t.Fatalf("unable to replace phase timer: %v", err)
+	}
+	db.Where("ticker = ?", "TEST").First(&token)
+
+	if len(first.phases) != 0 {
+		t.Errorf("expected the replaced timer to record nothing, got %v", first.phases)
+	}
+	if len(second.phases) != 1 || second.phases[0] != "query:token" {
+		t.Errorf("expected a single query phase, got %v", second.phases)
+	}
+}
+
+// fakeDriver is a database/sql driver whose connections do nothing, used to
+// inspect connection pool behaviour
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func TestConfigurePool(t *testing.T) {
+	sql.Register("fake-pool", fakeDriver{})
+	db, err := sql.Open("fake-pool", "")
+	if err != nil {
+		t.Fatalf("unable to open fake database: %v", err)
+	}
+	defer db.Close()
+
+	configurePool(db, Config{
+		DatabaseMaxOpenConns:    5,
+		DatabaseMaxIdleConns:    1,
+		DatabaseConnMaxLifetime: time.Minute,
+	})
+
+	if db.Stats().MaxOpenConnections != 5 {
+		t.Errorf("expected 5 max open connections, got %d", db.Stats().MaxOpenConnections)
+	}
+
+	// Open and release three connections, only one may stay idle
+	var conns []*sql.Conn
+	for n := 0; n < 3; n++ {
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("unable to open connection: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	if db.Stats().InUse != 3 {
+		t.Errorf("expected 3 connections in use, got %d", db.Stats().InUse)
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+	if db.Stats().Idle != 1 {
+		t.Errorf("expected 1 idle connection, got %d", db.Stats().Idle)
+	}
+}
@@ -0,0 +1,169 @@
+// Package dbtest provides a gorm database for tests that generates statements
+// without connecting to a database, and access to a Postgres test database
+// for the queries only a real database can check
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// Dialector is a gorm dialector that registers the default callbacks but has
+// no connection, use it with DryRun so statements are built and not executed
+type Dialector struct{}
+
+func (Dialector) Name() string { return "dryrun" }
+func (Dialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+func (Dialector) Migrator(db *gorm.DB) gorm.Migrator             { return nil }
+func (Dialector) DataTypeOf(*schema.Field) string                { return "" }
+func (Dialector) DefaultValueOf(*schema.Field) clause.Expression { return clause.Expr{} }
+func (Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+func (Dialector) QuoteTo(writer clause.Writer, str string) { writer.WriteString(str) }
+func (Dialector) Explain(sql string, vars ...interface{}) string {
+	return fmt.Sprint(sql, vars)
+}
+
+func (Dialector) SavePoint(tx *gorm.DB, name string) error {
+	return tx.Exec("SAVEPOINT " + name).Error
+}
+func (Dialector) RollbackTo(tx *gorm.DB, name string) error {
+	return tx.Exec("ROLLBACK TO SAVEPOINT " + name).Error
+}
+
+var errNoConnection = errors.New("dry run database has no connection")
+
+// ConnPool lets dry run databases begin transactions and counts how they
+// ended. It never runs statements, DryRun keeps gorm from using it for that
+type ConnPool struct {
+	Commits   int
+	Rollbacks int
+}
+
+func (pool *ConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errNoConnection
+}
+func (pool *ConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errNoConnection
+}
+func (pool *ConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errNoConnection
+}
+func (pool *ConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+func (pool *ConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &connTx{pool: pool}, nil
+}
+
+// connTx is a transaction on ConnPool. Like sql.Tx it can't begin another
+// transaction
+type connTx struct {
+	pool *ConnPool
+}
+
+func (tx *connTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errNoConnection
+}
+func (tx *connTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errNoConnection
+}
+func (tx *connTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errNoConnection
+}
+func (tx *connTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+func (tx *connTx) Commit() error {
+	tx.pool.Commits++
+	return nil
+}
+func (tx *connTx) Rollback() error {
+	tx.pool.Rollbacks++
+	return nil
+}
+
+// Open returns a dry run database
+func Open(t *testing.T) *gorm.DB {
+	return OpenWithPool(t, &ConnPool{})
+}
+
+// OpenWithPool returns a dry run database whose transactions are recorded in
+// pool
+func OpenWithPool(t *testing.T, pool *ConnPool) *gorm.DB {
+	db, err := gorm.Open(Dialector{}, &gorm.Config{DryRun: true, ConnPool: pool})
+	if err != nil {
+		t.Fatalf("unable to open dry run database: %v", err)
+	}
+	return db
+}
+
+// StubQueries makes queries on a dry run database return a copy of the
+// record with the same type as the destination. Queries for any other type
+// find no rows, which First reports as gorm.ErrRecordNotFound
+func StubQueries(t *testing.T, db *gorm.DB, records ...interface{}) {
+	byType := make(map[reflect.Type]interface{})
+	for _, record := range records {
+		byType[reflect.TypeOf(record)] = record
+	}
+	err := db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		record, ok := byType[reflect.TypeOf(tx.Statement.Dest)]
+		if !ok {
+			if tx.Statement.RaiseErrorOnNotFound {
+				tx.AddError(gorm.ErrRecordNotFound)
+			}
+			return
+		}
+		reflect.ValueOf(tx.Statement.Dest).Elem().Set(reflect.ValueOf(record).Elem())
+		tx.RowsAffected = 1
+	})
+	if err != nil {
+		t.Fatalf("unable to replace query callback: %v", err)
+	}
+}
+
+// OpenPostgres returns a transaction on the database in TEST_DATABASE_DSN,
+// which must have the migrations applied. The transaction is rolled back when
+// the test ends so tests don't see each other's rows. The test is skipped if
+// TEST_DATABASE_DSN is not set
+func OpenPostgres(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("unable to open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("unable to get test database connection: %v", err)
+	}
+	tx := db.Begin()
+	if tx.Error != nil {
+		t.Fatalf("unable to begin test transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() {
+		tx.Rollback()
+		sqlDB.Close()
+	})
+	return tx
+}
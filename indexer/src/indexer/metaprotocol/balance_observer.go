@@ -0,0 +1,83 @@
+package metaprotocol
+
+import (
+	"fmt"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"gorm.io/gorm"
+)
+
+// BalanceObserver is notified whenever a token holder's balance changes so
+// downstream caches can update without polling
+type BalanceObserver interface {
+	OnBalanceChange(chainID string, tokenID uint64, address string, oldAmount models.Amount, newAmount models.Amount)
+}
+
+// noopBalanceObserver is the default observer and ignores all changes
+type noopBalanceObserver struct{}
+
+func (noopBalanceObserver) OnBalanceChange(chainID string, tokenID uint64, address string, oldAmount models.Amount, newAmount models.Amount) {
+}
+
+// pendingBalanceChange is a balance change waiting for its transaction to
+// commit
+type pendingBalanceChange struct {
+	chainID   string
+	tokenID   uint64
+	address   string
+	oldAmount models.Amount
+	newAmount models.Amount
+}
+
+// pendingBalanceChanges collects the balance changes made inside a database
+// transaction so that observers don't hear about changes that are rolled back
+type pendingBalanceChanges struct {
+	changes []pendingBalanceChange
+}
+
+func (pending *pendingBalanceChanges) OnBalanceChange(chainID string, tokenID uint64, address string, oldAmount models.Amount, newAmount models.Amount) {
+	pending.changes = append(pending.changes, pendingBalanceChange{chainID, tokenID, address, oldAmount, newAmount})
+}
+
+// notify passes the collected changes on to observer in the order they were
+// made
+func (pending *pendingBalanceChanges) notify(observer BalanceObserver) {
+	for _, change := range pending.changes {
+		observer.OnBalanceChange(change.chainID, change.tokenID, change.address, change.oldAmount, change.newAmount)
+	}
+}
+
+// updateHolderBalance sets the balance of holder to amount, stores it and
+// notifies observer of the change. Existing holders are only updated if their
+// stored balance still matches holder.Amount, if another writer changed it in
+// the meantime an error matching ErrBalanceChanged is returned and nothing is
+// changed. Callers run it in the transaction of the whole operation so that
+// error undoes earlier writes
+func updateHolderBalance(db *gorm.DB, observer BalanceObserver, holder *models.TokenHolder, amount models.Amount) error {
+	oldAmount := holder.Amount
+	if holder.ID == 0 {
+		// New holders have no previous balance to race against
+		holder.Amount = amount
+		result := db.Save(holder)
+		if result.Error != nil {
+			holder.Amount = oldAmount
+			return result.Error
+		}
+	} else {
+		result := db.Model(&models.TokenHolder{}).
+			Where("id = ? AND amount = ?", holder.ID, oldAmount).
+			Updates(map[string]interface{}{
+				"amount":       amount,
+				"date_updated": holder.DateUpdated,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("balance of '%s' for token %d: %w", holder.Address, holder.TokenID, ErrBalanceChanged)
+		}
+		holder.Amount = amount
+	}
+	observer.OnBalanceChange(holder.ChainID, holder.TokenID, holder.Address, oldAmount, amount)
+	return nil
+}
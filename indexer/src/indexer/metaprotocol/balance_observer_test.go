@@ -0,0 +1,96 @@
+package metaprotocol
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/internal/dbtest"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"gorm.io/gorm"
+)
+
+// newRowsAffectedDB returns a dry run database where every update reports
+// rowsAffected rows and its SQL is recorded in statements
+func newRowsAffectedDB(t *testing.T, rowsAffected int64, statements *[]string) *gorm.DB {
+	db := dbtest.Open(t)
+	err := db.Callback().Update().After("gorm:update").Register("test:rows_affected", func(tx *gorm.DB) {
+		*statements = append(*statements, tx.Statement.SQL.String())
+		tx.RowsAffected = rowsAffected
+	})
+	if err != nil {
+		t.Fatalf("unable to register update callback: %v", err)
+	}
+	return db
+}
+
+// balanceChange is a recorded balance change with the amounts as strings so
+// changes can be compared
+type balanceChange struct {
+	address   string
+	oldAmount string
+	newAmount string
+}
+
+type recordingBalanceObserver struct {
+	changes []balanceChange
+}
+
+func (observer *recordingBalanceObserver) OnBalanceChange(chainID string, tokenID uint64, address string, oldAmount models.Amount, newAmount models.Amount) {
+	observer.changes = append(observer.changes, balanceChange{address, oldAmount.String(), newAmount.String()})
+}
+
+func TestUpdateHolderBalanceNotifiesObserver(t *testing.T) {
+	var statements []string
+	db := newRowsAffectedDB(t, 1, &statements)
+	observer := &recordingBalanceObserver{}
+
+	sender := models.TokenHolder{ID: 1, ChainID: "cosmoshub-4", TokenID: 1, Address: "sender", Amount: models.NewAmount(100)}
+	receiver := models.TokenHolder{ChainID: "cosmoshub-4", TokenID: 1, Address: "receiver"}
+
+	// Transfer 40 from the sender to a new receiver
+	if err := updateHolderBalance(db, observer, &sender, sender.Amount.Sub(models.NewAmount(40))); err != nil {
+		t.Fatalf("expected sender update to succeed, got %v", err)
+	}
+	if err := updateHolderBalance(db, observer, &receiver, receiver.Amount.Add(models.NewAmount(40))); err != nil {
+		t.Fatalf("expected receiver update to succeed, got %v", err)
+	}
+
+	expected := []balanceChange{
+		{"sender", "100", "60"},
+		{"receiver", "0", "40"},
+	}
+	if len(observer.changes) != len(expected) {
+		t.Fatalf("expected %d balance changes, got %d", len(expected), len(observer.changes))
+	}
+	for i, change := range expected {
+		if observer.changes[i] != change {
+			t.Errorf("expected change %+v, got %+v", change, observer.changes[i])
+		}
+	}
+	if sender.Amount.String() != "60" || receiver.Amount.String() != "40" {
+		t.Errorf("expected balances 60 and 40, got %s and %s", sender.Amount, receiver.Amount)
+	}
+}
+
+func TestUpdateHolderBalanceConcurrentModification(t *testing.T) {
+	var statements []string
+	// No rows match the expected balance, as if another writer got there first
+	db := newRowsAffectedDB(t, 0, &statements)
+	observer := &recordingBalanceObserver{}
+
+	holder := models.TokenHolder{ID: 1, ChainID: "cosmoshub-4", TokenID: 1, Address: "sender", Amount: models.NewAmount(100)}
+	err := updateHolderBalance(db, observer, &holder, models.NewAmount(60))
+	if !errors.Is(err, ErrBalanceChanged) {
+		t.Fatalf("expected concurrent modification to fail with ErrBalanceChanged, got %v", err)
+	}
+	if holder.Amount.String() != "100" {
+		t.Errorf("expected balance to stay 100, got %s", holder.Amount)
+	}
+	if len(observer.changes) != 0 {
+		t.Errorf("expected no balance changes, got %d", len(observer.changes))
+	}
+	if len(statements) != 1 || !strings.Contains(statements[0], "amount = ?") {
+		t.Errorf("expected update to be conditional on the old amount, got %v", statements)
+	}
+}
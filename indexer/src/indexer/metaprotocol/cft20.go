@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"mime"
 	"net/url"
 	"strconv"
@@ -29,20 +30,43 @@ type CFT20Config struct {
 	S3ID       string `envconfig:"S3_ID" required:"true"`
 	S3Secret   string `envconfig:"S3_SECRET" required:"true"`
 	S3Token    string `envconfig:"S3_TOKEN"`
+	// AmountPrecision is either "reject" or "truncate" and decides what
+	// happens to over-precise amounts unless a token overrides it
+	AmountPrecision string `envconfig:"AMOUNT_PRECISION" default:"reject"`
+	// CaseSensitiveTickers keeps tickers as given instead of upper-casing
+	// them. Changing it after deploying mixed-case tickers makes them
+	// unreachable
+	CaseSensitiveTickers bool `envconfig:"CASE_SENSITIVE_TICKERS" default:"false"`
+	// AmountMaxDecimals is the maximum number of fractional digits accepted
+	// in an amount, independent of the token's decimals
+	AmountMaxDecimals uint64 `envconfig:"AMOUNT_MAX_DECIMALS" default:"18"`
+	// StrictURNKeys rejects URNs that give a key more than once instead of
+	// using the last value. Leave it off when reindexing blocks from before
+	// duplicates were rejected
+	StrictURNKeys bool `envconfig:"STRICT_URN_KEYS" default:"false"`
 }
 
 type CFT20 struct {
-	chainID    string
-	db         *gorm.DB
-	s3Endpoint string
-	s3Region   string
-	s3Bucket   string
+	chainID         string
+	db              *gorm.DB
+	balanceObserver BalanceObserver
+	s3Endpoint      string
+	s3Region        string
+	s3Bucket        string
 	// s3ID is the S3 credentials ID
 	s3ID string
 	// s3Secret is the S3 credentials secret
 	s3Secret string
 	// s3Token is the S3 credentials token
 	s3Token string
+	// amountPrecision decides what happens to over-precise amounts
+	amountPrecision AmountPrecision
+	// caseSensitiveTickers keeps tickers as given instead of upper-casing
+	caseSensitiveTickers bool
+	// amountMaxDecimals bounds the fractional digits of any amount
+	amountMaxDecimals uint64
+	// strictURNKeys rejects URNs with duplicate keys
+	strictURNKeys bool
 	// Define protocol rules
 	nameMinLength          int
 	nameMaxLength          int
@@ -55,21 +79,30 @@ type CFT20 struct {
 
 func NewCFT20Processor(chainID string, db *gorm.DB) *CFT20 {
 	// Parse config environment variables for self
-	var config InscriptionConfig
+	var config CFT20Config
 	err := envconfig.Process("", &config)
 	if err != nil {
 		log.Fatalf("Unable to process config: %s", err)
 	}
+	amountPrecision, err := ParseAmountPrecision(config.AmountPrecision)
+	if err != nil {
+		log.Fatalf("Unable to process config: %s", err)
+	}
 
 	return &CFT20{
 		chainID:                chainID,
 		db:                     db,
+		balanceObserver:        noopBalanceObserver{},
 		s3Endpoint:             config.S3Endpoint,
 		s3Region:               config.S3Region,
 		s3Bucket:               config.S3Bucket,
 		s3ID:                   config.S3ID,
 		s3Secret:               config.S3Secret,
 		s3Token:                config.S3Token,
+		amountPrecision:        amountPrecision,
+		caseSensitiveTickers:   config.CaseSensitiveTickers,
+		amountMaxDecimals:      config.AmountMaxDecimals,
+		strictURNKeys:          config.StrictURNKeys,
 		nameMinLength:          1,
 		nameMaxLength:          32,
 		tickerMinLength:        1,
@@ -84,8 +117,36 @@ func (protocol *CFT20) Name() string {
 	return "cft20"
 }
 
+func (protocol *CFT20) Version() string {
+	return "1.0.0"
+}
+
+// SetBalanceObserver sets the observer notified of token balance changes
+func (protocol *CFT20) SetBalanceObserver(observer BalanceObserver) {
+	protocol.balanceObserver = observer
+}
+
+// Process applies the operation in protocolURN in a single database
+// transaction so a failure part way through leaves no partial changes behind.
+// Balance observers are only notified once the transaction has committed
 func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error {
+	changes := &pendingBalanceChanges{}
+	err := protocol.db.Transaction(func(tx *gorm.DB) error {
+		return protocol.process(tx, changes, transactionModel, protocolURN, rawTransaction)
+	})
+	if err != nil {
+		return err
+	}
+	changes.notify(protocol.balanceObserver)
+	return nil
+}
+
+func (protocol *CFT20) process(db *gorm.DB, observer BalanceObserver, transactionModel models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error {
 	sender, err := rawTransaction.GetSenderAddress()
+	if err != nil {
+		return InvalidOperation(err)
+	}
+	sender, err = NormalizeAddress(sender)
 	if err != nil {
 		return err
 	}
@@ -94,13 +155,18 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 	// {chainId}@{version};operation$h={unique hash of content}
 	// cosmoshub-4@v1beta;deploy$nam=The Name,tic=TICK,sup=21000000,dec=6,lim=1000
 	// cosmoshub-4@v1beta;deploy$nam=NewYearDay,tic=NYD,sup=28000000,dec=18,lim=50000,opn=1704059940
-	parsedURN, err := ParseProtocolString(protocolURN)
+	parsedURN, err := ParseProtocolString(protocolURN, protocol.strictURNKeys)
 	if err != nil {
 		return err
 	}
 
 	if parsedURN.ChainID != protocol.chainID {
-		return fmt.Errorf("chain ID in protocol string does not match transaction chain ID")
+		return invalidOperationf("chain ID in protocol string does not match transaction chain ID")
+	}
+
+	err = parsedURN.CheckExpiry(transactionModel.Height)
+	if err != nil {
+		return err
 	}
 
 	// TODO: Rework the operation handling
@@ -108,73 +174,57 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 	case "deploy":
 		name, err := url.QueryUnescape(strings.TrimSpace(parsedURN.KeyValuePairs["nam"]))
 		if err != nil {
-			return fmt.Errorf("unable to parse token name '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse token name '%w'", err))
 		}
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
-		supplyFloat, err := strconv.ParseFloat(parsedURN.KeyValuePairs["sup"], 64)
+		decimals, err := strconv.ParseUint(parsedURN.KeyValuePairs["dec"], 10, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse supply '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse decimals '%w'", err))
 		}
-		if supplyFloat <= 0 {
-			return fmt.Errorf("token supply must be greater than 0")
+		if decimals > uint64(protocol.decimalsMaxValue) {
+			return invalidOperationf("token decimals must be less than %d", protocol.decimalsMaxValue)
 		}
 
-		decimals, err := strconv.ParseUint(parsedURN.KeyValuePairs["dec"], 10, 64)
+		// Add the decimals to the supply and limit
+		supplyString := strings.TrimSpace(parsedURN.KeyValuePairs["sup"])
+		supply, err := ValidateAndScaleAmountWithPrecision(supplyString, uint(decimals), big.NewInt(1), new(big.Int).SetUint64(protocol.maxSupplyMaxValue), AmountPrecisionReject, protocol.amountMaxDecimals)
 		if err != nil {
-			return fmt.Errorf("unable to parse decimals '%s'", err)
+			return fmt.Errorf("invalid supply '%w'", err)
 		}
-		limitFloat, err := strconv.ParseFloat(parsedURN.KeyValuePairs["lim"], 64)
+		limitString := strings.TrimSpace(parsedURN.KeyValuePairs["lim"])
+		limit, err := ValidateAndScaleAmountWithPrecision(limitString, uint(decimals), big.NewInt(1), supply.BigInt(), AmountPrecisionReject, protocol.amountMaxDecimals)
 		if err != nil {
-			return fmt.Errorf("unable to parse limit '%s'", err)
-		}
-		if limitFloat <= 0 {
-			return fmt.Errorf("token supply must be greater than 0")
+			return fmt.Errorf("invalid limit '%w'", err)
 		}
 
 		openTimestamp, err := strconv.ParseUint(parsedURN.KeyValuePairs["opn"], 10, 64)
 		if err != nil {
 			// If this fails, we set the open time to the block time
-			openTimestamp = uint64(transactionModel.DateCreated.Unix())
+			openTimestamp, err = unixTimestamp(transactionModel.DateCreated)
+			if err != nil {
+				return err
+			}
 		}
 
-		// Add the decimals to the supply and limit
-		supplyFloat = supplyFloat * math.Pow10(int(decimals))
-		supply := uint64(math.Round(supplyFloat))
-
-		limitFloat = limitFloat * math.Pow10(int(decimals))
-		limit := uint64(math.Round(limitFloat))
-
 		// TODO: Rework validation
 		// Validate some fields
 		if len(name) < protocol.nameMinLength || len(name) > protocol.nameMaxLength {
-			return fmt.Errorf("token name must be between %d and %d characters", protocol.nameMinLength, protocol.nameMaxLength)
+			return invalidOperationf("token name must be between %d and %d characters", protocol.nameMinLength, protocol.nameMaxLength)
 		}
 		if len(ticker) < protocol.tickerMinLength || len(ticker) > protocol.tickerMaxLength {
-			return fmt.Errorf("token ticker must be between %d and %d characters", protocol.tickerMinLength, protocol.tickerMaxLength)
-		}
-		if decimals > uint64(protocol.decimalsMaxValue) {
-			return fmt.Errorf("token decimals must be less than %d", protocol.decimalsMaxValue)
-		}
-		if supply > protocol.maxSupplyMaxValue {
-			return fmt.Errorf("token supply must be less than %d", protocol.maxSupplyMaxValue)
+			return invalidOperationf("token ticker must be between %d and %d characters", protocol.tickerMinLength, protocol.tickerMaxLength)
 		}
 		// Minting limit may be at most 1% of supply
-		maxMintLimit := supplyFloat * 0.01
-		if limitFloat > maxMintLimit {
-			return fmt.Errorf("the mint limit may not exceed 1%% of the total supply")
-		}
-
-		if limit > supply {
-			return fmt.Errorf("token per wallet limit must be less than supply of %d", protocol.maxSupplyMaxValue)
+		if limit.GT(supply.QuoUint64(100)) {
+			return invalidOperationf("the mint limit may not exceed 1%% of the total supply")
 		}
 
 		// Check if this token has already been deployed
 		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
+		result := db.Unscoped().Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
 		if result.Error == nil {
-			return fmt.Errorf("token with ticker '%s' already exists", ticker)
+			return invalidOperationf("token with ticker '%s' already exists", ticker)
 		}
 
 		// TODO: Rework the content extraction
@@ -188,7 +238,7 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			for _, extension := range rawTransaction.Body.NonCriticalExtensionOptions {
 				msg, err = extension.UnmarshalData()
 				if err != nil {
-					return fmt.Errorf("unable to unmarshal extension data '%s'", err)
+					return InvalidOperation(fmt.Errorf("unable to unmarshal extension data '%w'", err))
 				}
 
 				// We only process the first extension option
@@ -197,18 +247,18 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 
 			inscriptionMetadata, err := msg.GetMetadata()
 			if err != nil {
-				return err
+				return InvalidOperation(err)
 			}
 
 			content, err := msg.GetContent()
 			if err != nil {
-				return err
+				return InvalidOperation(err)
 			}
 
 			// Store the content with the correct mime type on DO
 			contentPath, err = protocol.storeContent(inscriptionMetadata, rawTransaction.Hash, content)
 			if err != nil {
-				return fmt.Errorf("unable to store content '%s'", err)
+				return fmt.Errorf("unable to store content '%w'", err)
 			}
 
 			contentLength = len(content)
@@ -231,39 +281,40 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			ContentPath:       contentPath,
 			ContentSizeBytes:  uint64(contentLength),
 			DateCreated:       transactionModel.DateCreated,
-			CirculatingSupply: 0,
+			CirculatingSupply: models.NewAmount(0),
 		}
 
-		result = protocol.db.Save(&tokenModel)
+		result = db.Save(&tokenModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 	case "mint":
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
 		// Check if the ticker exists
-		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
-		if result.Error != nil {
-			return fmt.Errorf("token with ticker '%s' doesn't exist", ticker)
+		tokenModel, err := findToken(db, parsedURN.ChainID, ticker, parsedURN.KeyValuePairs["tid"])
+		if err != nil {
+			return err
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", ticker)
 		}
 		// Check if the minted <= max supply
-		if tokenModel.CirculatingSupply >= tokenModel.MaxSupply {
-			return fmt.Errorf("token with ticker '%s' has reached max supply", ticker)
+		if tokenModel.RemainingSupply().IsZero() {
+			return invalidOperationf("token with ticker '%s' has reached max supply", ticker)
 		}
 		// Check if opn time < transaction time
-		if tokenModel.LaunchTimestamp > uint64(transactionModel.DateCreated.Unix()) {
-			return fmt.Errorf("token with ticker '%s' is not yet open for minting", ticker)
+		transactionTimestamp, err := unixTimestamp(transactionModel.DateCreated)
+		if err != nil {
+			return err
 		}
-
-		mintAmount := tokenModel.PerMintLimit
-		if tokenModel.CirculatingSupply+mintAmount > tokenModel.MaxSupply {
-			// Determine if there is anything left to mint
-			mintAmount = tokenModel.MaxSupply - tokenModel.CirculatingSupply
+		if tokenModel.LaunchTimestamp > transactionTimestamp {
+			return invalidOperationf("token with ticker '%s' is not yet open for minting", ticker)
 		}
 
+		mintAmount := tokenModel.NextMintAmount()
+
 		// Add to tx history, we do this first so that if this tx has been processed
 		// we don't alter anything else
 		historyModel := models.TokenAddressHistory{
@@ -277,21 +328,21 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			Amount:        mintAmount,
 			DateCreated:   transactionModel.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
+		result := db.Save(&historyModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 		// Update token circulating
-		tokenModel.CirculatingSupply = tokenModel.CirculatingSupply + mintAmount
-		result = protocol.db.Save(&tokenModel)
+		tokenModel.CirculatingSupply = tokenModel.CirculatingSupply.Add(mintAmount)
+		result = db.Save(&tokenModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 		// Update user balance
 		var holderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
+		result = db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
 		if result.Error != nil {
 			if result.Error != gorm.ErrRecordNotFound {
 				return result.Error
@@ -301,73 +352,73 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 		holderModel.ChainID = parsedURN.ChainID
 		holderModel.TokenID = tokenModel.ID
 		holderModel.Address = sender
-		holderModel.Amount = holderModel.Amount + mintAmount
 		holderModel.DateUpdated = transactionModel.DateCreated
 
-		result = protocol.db.Save(&holderModel)
-		if result.Error != nil {
-			return result.Error
+		err = updateHolderBalance(db, observer, &holderModel, holderModel.Amount.Add(mintAmount))
+		if err != nil {
+			return err
 		}
 
 	case "transfer":
 
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
 		// Check if the ticker exists
-		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
-		if result.Error != nil {
-			return fmt.Errorf("token with ticker '%s' doesn't exist", ticker)
+		tokenModel, err := findToken(db, parsedURN.ChainID, ticker, parsedURN.KeyValuePairs["tid"])
+		if err != nil {
+			return err
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", ticker)
 		}
 
 		// Check required fields
-		destinationAddress := strings.TrimSpace(parsedURN.KeyValuePairs["dst"])
-		destinationAddress = strings.ToLower(destinationAddress)
+		destinationAddress, err := NormalizeAddress(parsedURN.KeyValuePairs["dst"])
+		if err != nil {
+			return invalidOperationf("destination address does not look like a valid address")
+		}
 		if len(destinationAddress) != 45 {
-			return fmt.Errorf("cosmos hub addresses must be 45 characters long")
+			return invalidOperationf("cosmos hub addresses must be 45 characters long")
 		}
-		if !strings.Contains(destinationAddress, "cosmos1") {
-			return fmt.Errorf("destination address does not look like a valid address")
+		if !strings.HasPrefix(destinationAddress, "cosmos1") {
+			return invalidOperationf("destination address does not look like a valid address")
 		}
 
 		amountString := strings.TrimSpace(parsedURN.KeyValuePairs["amt"])
 		// Convert amount to have the correct number of decimals
-		amount, err := strconv.ParseUint(amountString, 10, 64)
+		precision, err := tokenAmountPrecision(protocol.amountPrecision, tokenModel)
 		if err != nil {
-			return fmt.Errorf("unable to parse amount '%s'", err)
+			return err
 		}
-		if amount == 0 {
-			return fmt.Errorf("amount must be greater than 0")
+		amount, err := ValidateAndScaleAmountWithPrecision(amountString, uint(tokenModel.Decimals), big.NewInt(1), tokenMaxAmount(tokenModel), precision, protocol.amountMaxDecimals)
+		if err != nil {
+			return fmt.Errorf("unable to parse amount '%w'", err)
 		}
 
-		amount = amount * uint64(math.Pow10(int(tokenModel.Decimals)))
-
 		// Check that the user has enough tokens to transfer
 		var holderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
+		result := db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
 		if result.Error != nil {
-			return fmt.Errorf("sender does not have any tokens to transfer")
+			return invalidOperationf("sender does not have any tokens to transfer")
 		}
 
-		if holderModel.Amount < amount {
-			return fmt.Errorf("sender does not have enough tokens to transfer")
+		if holderModel.Amount.LT(amount) {
+			return invalidOperationf("sender does not have enough tokens to transfer")
 		}
 
 		// At this point we know that the sender has enough tokens to transfer
 		// so update the sender's balance
-		holderModel.Amount = holderModel.Amount - amount
-		result = protocol.db.Save(&holderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update sender balance '%s'", err)
+		err = updateHolderBalance(db, observer, &holderModel, holderModel.Amount.Sub(amount))
+		if err != nil {
+			return fmt.Errorf("unable to update sender balance '%w'", err)
 		}
 
 		// Check if the destination address has any tokens
 		var destinationHolderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, destinationAddress).First(&destinationHolderModel)
+		result = db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, destinationAddress).First(&destinationHolderModel)
 		if result.Error != nil {
 			if result.Error != gorm.ErrRecordNotFound {
-				return fmt.Errorf("unable to check destination balance '%s'", err)
+				return fmt.Errorf("unable to check destination balance '%w'", result.Error)
 			}
 		}
 
@@ -375,12 +426,11 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 		destinationHolderModel.ChainID = parsedURN.ChainID
 		destinationHolderModel.TokenID = tokenModel.ID
 		destinationHolderModel.Address = destinationAddress
-		destinationHolderModel.Amount = destinationHolderModel.Amount + amount
 		destinationHolderModel.DateUpdated = transactionModel.DateCreated
 
-		result = protocol.db.Save(&destinationHolderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update receiver balance '%s'", err)
+		err = updateHolderBalance(db, observer, &destinationHolderModel, destinationHolderModel.Amount.Add(amount))
+		if err != nil {
+			return fmt.Errorf("unable to update receiver balance '%w'", err)
 		}
 
 		// Record the transfer
@@ -395,21 +445,22 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			Amount:        amount,
 			DateCreated:   transactionModel.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
+		result = db.Save(&historyModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 	case "list":
 
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
 		// Check if the ticker exists
-		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
-		if result.Error != nil {
-			return fmt.Errorf("token with ticker '%s' doesn't exist", ticker)
+		tokenModel, err := findToken(db, parsedURN.ChainID, ticker, parsedURN.KeyValuePairs["tid"])
+		if err != nil {
+			return err
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", ticker)
 		}
 
 		// Set the destination address to the marketplace for transfer history
@@ -418,48 +469,58 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 		// Check required fields
 		amountString := strings.TrimSpace(parsedURN.KeyValuePairs["amt"])
 		// Convert amount to have the correct number of decimals
-		amount, err := strconv.ParseFloat(amountString, 64)
+		precision, err := tokenAmountPrecision(protocol.amountPrecision, tokenModel)
 		if err != nil {
-			return fmt.Errorf("unable to parse amount '%s'", err)
+			return err
 		}
-		if amount <= 0 {
-			return fmt.Errorf("amount must be greater than 0")
+		amount, err := ValidateAndScaleAmountWithPrecision(amountString, uint(tokenModel.Decimals), big.NewInt(1), tokenMaxAmount(tokenModel), precision, protocol.amountMaxDecimals)
+		if err != nil {
+			return fmt.Errorf("unable to parse amount '%w'", err)
 		}
 
 		pptString := strings.TrimSpace(parsedURN.KeyValuePairs["ppt"])
 		// Convert amount to have the correct number of decimals
 		ppt, err := strconv.ParseFloat(pptString, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse ppt '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse ppt '%w'", err))
 		}
 		if ppt <= 0 {
-			return fmt.Errorf("price per token must be greater than 0")
+			return invalidOperationf("price per token must be greater than 0")
 		}
 
-		totalBase := float64(amount) * ppt
+		totalBase := amount.Float64() / math.Pow10(int(tokenModel.Decimals)) * ppt
 
 		// 6 is the amount of ATOM decimals
 		ppt = ppt * math.Pow10(6)
-		amount = amount * math.Pow10(int(tokenModel.Decimals))
 		totalBase = totalBase * math.Pow10(6)
 
+		// Convert the base amounts before anything is changed so that values
+		// that don't fit in a uint64 are rejected instead of truncated
+		pptBase, err := toUint64(math.Round(ppt))
+		if err != nil {
+			return fmt.Errorf("invalid price per token '%w'", err)
+		}
+		totalBaseAmount, err := toUint64(math.Round(totalBase))
+		if err != nil {
+			return fmt.Errorf("invalid total '%w'", err)
+		}
+
 		// Check that the user has enough tokens to sell
 		var holderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
+		result := db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
 		if result.Error != nil {
-			return fmt.Errorf("sender does not have any tokens to sell")
+			return invalidOperationf("sender does not have any tokens to sell")
 		}
 
-		if holderModel.Amount < uint64(amount) {
-			return fmt.Errorf("sender does not have enough tokens to sell")
+		if holderModel.Amount.LT(amount) {
+			return invalidOperationf("sender does not have enough tokens to sell")
 		}
 
 		// At this point we know that the sender has enough tokens to sell
 		// so update the sender's balance
-		holderModel.Amount = holderModel.Amount - uint64(amount)
-		result = protocol.db.Save(&holderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update seller's balance '%s'", err)
+		err = updateHolderBalance(db, observer, &holderModel, holderModel.Amount.Sub(amount))
+		if err != nil {
+			return fmt.Errorf("unable to update seller's balance '%w'", err)
 		}
 
 		// Create a sell position
@@ -468,15 +529,15 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			TransactionID: transactionModel.ID,
 			TokenID:       tokenModel.ID,
 			SellerAddress: sender,
-			Amount:        uint64(math.Round(amount)),
-			PPT:           uint64(math.Round(ppt)),
-			Total:         uint64(math.Round(totalBase)),
+			Amount:        amount,
+			PPT:           pptBase,
+			Total:         totalBaseAmount,
 			DateCreated:   transactionModel.DateCreated,
 		}
 
-		result = protocol.db.Save(&positionModel)
+		result = db.Save(&positionModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to create sell position '%s'", result.Error)
+			return fmt.Errorf("unable to create sell position '%w'", result.Error)
 		}
 
 		// Record the transfer
@@ -488,33 +549,34 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			Sender:        sender,
 			Receiver:      destinationAddress,
 			Action:        "list",
-			Amount:        uint64(math.Round(amount)),
+			Amount:        amount,
 			DateCreated:   transactionModel.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
+		result = db.Save(&historyModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 	case "buy":
 
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
 		// Check if the ticker exists
-		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
-		if result.Error != nil {
-			return fmt.Errorf("token with ticker '%s' doesn't exist", ticker)
+		tokenModel, err := findToken(db, parsedURN.ChainID, ticker, parsedURN.KeyValuePairs["tid"])
+		if err != nil {
+			return err
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", ticker)
 		}
 
 		orderNumber := strings.TrimSpace(parsedURN.KeyValuePairs["ord"])
 
 		// Check if the order still exists
 		var openOrderModel models.TokenOpenPosition
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND id = ? AND is_filled = ? AND is_cancelled = ?", parsedURN.ChainID, tokenModel.ID, orderNumber, false, false).First(&openOrderModel)
+		result := db.Where("chain_id = ? AND token_id = ? AND id = ? AND is_filled = ? AND is_cancelled = ?", parsedURN.ChainID, tokenModel.ID, orderNumber, false, false).First(&openOrderModel)
 		if result.Error != nil {
-			return fmt.Errorf("order by id '%s' doesn't exist", orderNumber)
+			return invalidOperationf("order by id '%s' doesn't exist", orderNumber)
 		}
 
 		// Check if the amount sent >= amount required
@@ -522,13 +584,13 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			if v.Type == "/cosmos.bank.v1beta1.MsgSend" {
 				// The first send should hold the amount being used to buy the tokens with
 				if v.Amount[0].Amount != fmt.Sprintf("%d", openOrderModel.Total) {
-					return fmt.Errorf("incorrect amount sent to buy tokens, got %s, expected %d", v.Amount[0].Amount, openOrderModel.Total)
+					return invalidOperationf("incorrect amount sent to buy tokens, got %s, expected %d", v.Amount[0].Amount, openOrderModel.Total)
 				}
 				if v.Amount[0].Denom != "uatom" {
-					return fmt.Errorf("incorrect denom sent to buy tokens, got %s, expected uatom", v.Amount[0].Denom)
+					return invalidOperationf("incorrect denom sent to buy tokens, got %s, expected uatom", v.Amount[0].Denom)
 				}
 				if v.ToAddress != openOrderModel.SellerAddress {
-					return fmt.Errorf("attempting to buy from incorrect seller")
+					return invalidOperationf("attempting to buy from incorrect seller")
 				}
 				break
 			}
@@ -536,33 +598,33 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 
 		// Get current USD price of the base
 		var statusModel models.Status
-		result = protocol.db.Where("chain_id = ?", parsedURN.ChainID).First(&statusModel)
+		result = db.Where("chain_id = ?", parsedURN.ChainID).First(&statusModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to get current base currency price '%s'", err)
+			return fmt.Errorf("unable to get current base currency price '%w'", result.Error)
 		}
 
 		// We no longer update the price from the previous market
 		// tokenModel.LastPriceBase = openOrderModel.PPT
-		// result = protocol.db.Save(&tokenModel)
+		// result = db.Save(&tokenModel)
 		// if result.Error != nil {
-		// 	return fmt.Errorf("unable to update token price '%s'", result.Error)
+		// 	return fmt.Errorf("unable to update token price '%w'", result.Error)
 		// }
 
 		// Everything checks out, so we can mark the order as filled and transfer the tokens
 		openOrderModel.IsFilled = true
 		openOrderModel.DateFilled = transactionModel.DateCreated
-		result = protocol.db.Save(&openOrderModel)
+		result = db.Save(&openOrderModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to update order '%s'", result.Error)
+			return fmt.Errorf("unable to update order '%w'", result.Error)
 		}
 
 		// Update the buyer's balance
 		// Check if the destination address has any tokens
 		var destinationHolderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&destinationHolderModel)
+		result = db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&destinationHolderModel)
 		if result.Error != nil {
 			if result.Error != gorm.ErrRecordNotFound {
-				return fmt.Errorf("unable to check destination balance '%s'", result.Error)
+				return fmt.Errorf("unable to check destination balance '%w'", result.Error)
 			}
 		}
 
@@ -570,12 +632,11 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 		destinationHolderModel.ChainID = parsedURN.ChainID
 		destinationHolderModel.TokenID = tokenModel.ID
 		destinationHolderModel.Address = sender
-		destinationHolderModel.Amount = destinationHolderModel.Amount + openOrderModel.Amount
 		destinationHolderModel.DateUpdated = transactionModel.DateCreated
 
-		result = protocol.db.Save(&destinationHolderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update receiver balance '%s'", err)
+		err = updateHolderBalance(db, observer, &destinationHolderModel, destinationHolderModel.Amount.Add(openOrderModel.Amount))
+		if err != nil {
+			return fmt.Errorf("unable to update receiver balance '%w'", err)
 		}
 
 		// Record the transfer
@@ -590,7 +651,7 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			Amount:        openOrderModel.Amount,
 			DateCreated:   transactionModel.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
+		result = db.Save(&historyModel)
 		if result.Error != nil {
 			return result.Error
 		}
@@ -610,72 +671,73 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			TotalUSD:      totalWithDecimals * statusModel.BaseTokenUSD,
 			DateCreated:   transactionModel.DateCreated,
 		}
-		result = protocol.db.Save(&tradeHistory)
+		result = db.Save(&tradeHistory)
 		if result.Error != nil {
 			return result.Error
 		}
 
 		// Recalculate volume from filled trades for this token in past 24 hours
 		// SELECT sum(total_usd) from token_trade_history where date_Created >= now - 24 hours and token_id = this token id
-		var sum uint64
-		err = protocol.db.Model(&models.TokenTradeHistory{}).
-			Select("SUM(amount_quote)").
-			Where("date_created >= ?", time.Now().Add(-24*time.Hour)).
-			Where("token_id = ?", tokenModel.ID).
-			Find(&sum).Error
+		err = runOptional(db, func(tx *gorm.DB) error {
+			var sum uint64
+			err := tx.Model(&models.TokenTradeHistory{}).
+				Select("SUM(amount_quote)").
+				Where("date_created >= ?", time.Now().Add(-24*time.Hour)).
+				Where("token_id = ?", tokenModel.ID).
+				Find(&sum).Error
+			if err != nil {
+				return err
+			}
 
+			tokenModel.Volume24Base = sum
+			return tx.Save(&tokenModel).Error
+		})
 		if err != nil {
 			// No need to alert the buyer
 			return nil
 		}
 
-		tokenModel.Volume24Base = sum
-		result = protocol.db.Save(&tokenModel)
-		if result.Error != nil {
-			// No need to alert the buyer
-			return nil
-		}
-
 	case "delist":
 
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
 		// Check if the ticker exists
-		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
-		if result.Error != nil {
-			return fmt.Errorf("token with ticker '%s' doesn't exist", ticker)
+		tokenModel, err := findToken(db, parsedURN.ChainID, ticker, parsedURN.KeyValuePairs["tid"])
+		if err != nil {
+			return err
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", ticker)
 		}
 
 		orderNumber := strings.TrimSpace(parsedURN.KeyValuePairs["ord"])
 
 		// Check if the order still exists
 		var openOrderModel models.TokenOpenPosition
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND id = ? AND is_filled = ? AND is_cancelled = ?", parsedURN.ChainID, tokenModel.ID, orderNumber, false, false).First(&openOrderModel)
+		result := db.Where("chain_id = ? AND token_id = ? AND id = ? AND is_filled = ? AND is_cancelled = ?", parsedURN.ChainID, tokenModel.ID, orderNumber, false, false).First(&openOrderModel)
 		if result.Error != nil {
-			return fmt.Errorf("order by id '%s' doesn't exist", orderNumber)
+			return invalidOperationf("order by id '%s' doesn't exist", orderNumber)
 		}
 
 		// Check if the sender is the owner of the order
 		if openOrderModel.SellerAddress != sender {
-			return fmt.Errorf("only the seller can cancel an order")
+			return invalidOperationf("only the seller can cancel an order")
 		}
 
 		// Everything checks out, so we can mark the order as cancelled
 		openOrderModel.IsCancelled = true
-		result = protocol.db.Save(&openOrderModel)
+		result = db.Save(&openOrderModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to update order '%s'", err)
+			return fmt.Errorf("unable to update order '%w'", result.Error)
 		}
 
 		// Return funds to seller
 		// Check if the destination address has any tokens
 		var destinationHolderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&destinationHolderModel)
+		result = db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&destinationHolderModel)
 		if result.Error != nil {
 			if result.Error != gorm.ErrRecordNotFound {
-				return fmt.Errorf("unable to check destination balance '%s'", err)
+				return fmt.Errorf("unable to check destination balance '%w'", result.Error)
 			}
 		}
 
@@ -683,12 +745,11 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 		destinationHolderModel.ChainID = parsedURN.ChainID
 		destinationHolderModel.TokenID = tokenModel.ID
 		destinationHolderModel.Address = sender
-		destinationHolderModel.Amount = destinationHolderModel.Amount + openOrderModel.Amount
 		destinationHolderModel.DateUpdated = transactionModel.DateCreated
 
-		result = protocol.db.Save(&destinationHolderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update receiver balance '%s'", err)
+		err = updateHolderBalance(db, observer, &destinationHolderModel, destinationHolderModel.Amount.Add(openOrderModel.Amount))
+		if err != nil {
+			return fmt.Errorf("unable to update receiver balance '%w'", err)
 		}
 
 		// Record the transfer
@@ -703,7 +764,7 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 			Amount:        openOrderModel.Amount,
 			DateCreated:   transactionModel.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
+		result = db.Save(&historyModel)
 		if result.Error != nil {
 			return result.Error
 		}
@@ -712,6 +773,54 @@ func (protocol *CFT20) Process(transactionModel models.Transaction, protocolURN
 	return nil
 }
 
+// maxTopHolders is the largest page of holders TopHolders returns
+const maxTopHolders = 100
+
+// TopHolders returns a page of up to limit holders of tokenID with a
+// non-zero balance, largest balance first, after skipping offset holders.
+// Holders with the same balance are ordered by when they first held the token
+// so pages don't overlap
+func (protocol *CFT20) TopHolders(chainID string, tokenID uint64, limit int, offset int) ([]models.TokenHolder, error) {
+	if limit < 1 || limit > maxTopHolders {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxTopHolders)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset can't be negative")
+	}
+	var holders []models.TokenHolder
+	result := protocol.db.Where("chain_id = ? AND token_id = ? AND amount > 0", chainID, tokenID).Order("amount DESC, id ASC").Limit(limit).Offset(offset).Find(&holders)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return holders, nil
+}
+
+// PruneZeroHolders deletes holder records with a zero balance on chainID
+// and returns the number of records removed. Holders with an open
+// marketplace listing for the token are kept, cancelling the listing
+// returns the tokens to the existing holder record. It must not run while
+// transactions are processed, a holder read by a processor could be deleted
+// before its new balance is written
+func (protocol *CFT20) PruneZeroHolders(chainID string) (int, error) {
+	result := protocol.db.Exec(`
+		DELETE FROM token_holder th
+		WHERE th.chain_id = ?
+		AND th.amount = 0
+		AND NOT EXISTS (
+			SELECT 1
+			FROM marketplace_listing ml
+			JOIN marketplace_cft20_detail mcd ON mcd.listing_id = ml.id
+			WHERE ml.seller_address = th.address
+			AND mcd.token_id = th.token_id
+			AND ml.is_filled = false
+			AND ml.is_cancelled = false
+		)`, chainID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
 // TODO: This is reused, move to common helpers
 // storeContent stores the content in the S3 bucket
 func (protocol *CFT20) storeContent(metadata *types.InscriptionMetadata, txHash string, content []byte) (string, error) {
@@ -747,7 +856,7 @@ func (protocol *CFT20) storeContent(metadata *types.InscriptionMetadata, txHash
 		ContentType: aws.String(metadata.Metadata.Mime),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to upload file, %v", err)
+		return "", fmt.Errorf("failed to upload file, %w", err)
 	}
 
 	return aws.StringValue(&uploadResult.Location), nil
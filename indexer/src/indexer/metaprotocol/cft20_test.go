@@ -0,0 +1,471 @@
+package metaprotocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/internal/dbtest"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+func TestProcessTransferRollsBack(t *testing.T) {
+	pool := &dbtest.ConnPool{}
+	db := dbtest.OpenWithPool(t, pool)
+	holders := 0
+	err := db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *models.Token:
+			*dest = models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST", Decimals: 6, MaxSupply: models.NewAmount(21000000000000)}
+			tx.RowsAffected = 1
+		case *models.TokenHolder:
+			holders++
+			*dest = models.TokenHolder{ID: uint64(holders), ChainID: "cosmoshub-4", TokenID: 1, Amount: models.NewAmount(5000000)}
+			tx.RowsAffected = 1
+		}
+	})
+	if err != nil {
+		t.Fatalf("unable to replace query callback: %v", err)
+	}
+	// The sender is debited, then the receiver's balance changes before it
+	// is credited
+	var updates []string
+	err = db.Callback().Update().After("gorm:update").Register("test:rows_affected", func(tx *gorm.DB) {
+		updates = append(updates, tx.Statement.SQL.String())
+		if len(updates) > 1 {
+			tx.RowsAffected = 0
+			return
+		}
+		tx.RowsAffected = 1
+	})
+	if err != nil {
+		t.Fatalf("unable to register update callback: %v", err)
+	}
+	observer := &recordingBalanceObserver{}
+	protocol := &CFT20{chainID: "cosmoshub-4", db: db, balanceObserver: observer, amountPrecision: AmountPrecisionReject}
+
+	var rawTransaction types.RawTransaction
+	err = json.Unmarshal([]byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"}]}}`), &rawTransaction)
+	if err != nil {
+		t.Fatalf("invalid test transaction: %v", err)
+	}
+	protocolURN := parseTestURN(t, "urn:cft20:cosmoshub-4@v1;transfer$tic=TEST,amt=1,dst=cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95")
+
+	err = protocol.Process(models.Transaction{ID: 1, Height: 100}, protocolURN, rawTransaction)
+	if !errors.Is(err, ErrBalanceChanged) {
+		t.Fatalf("expected transfer to fail when the receiver was modified concurrently, got %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("expected the debit and credit to be attempted, got %v", updates)
+	}
+	if pool.Rollbacks != 1 || pool.Commits != 0 {
+		t.Errorf("expected the debit to be rolled back, got %d rollbacks and %d commits", pool.Rollbacks, pool.Commits)
+	}
+	if len(observer.changes) != 0 {
+		t.Errorf("expected no balance changes to be reported, got %+v", observer.changes)
+	}
+}
+
+func TestProcessBuyKeepsTradeWhenVolumeUpdateFails(t *testing.T) {
+	seller := "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95"
+	buyer := "cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"
+	errTimeout := errors.New("canceling statement due to statement timeout")
+
+	// The volume is recalculated with a query and stored with an update of
+	// the token, a failure in either must not undo the buy
+	for _, failing := range []string{"query", "update"} {
+		pool := &dbtest.ConnPool{}
+		db := dbtest.OpenWithPool(t, pool)
+		dbtest.StubQueries(t, db,
+			&models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST", Decimals: 6, MaxSupply: models.NewAmount(21000000000000)},
+			&models.TokenOpenPosition{ID: 5, ChainID: "cosmoshub-4", TokenID: 1, SellerAddress: seller, Amount: models.NewAmount(1000000), Total: 2000000},
+			&models.Status{ChainID: "cosmoshub-4", BaseTokenUSD: 10},
+		)
+		err := db.Callback().Query().After("gorm:query").Register("test:fail_volume", func(tx *gorm.DB) {
+			if _, ok := tx.Statement.Dest.(*uint64); ok && failing == "query" {
+				tx.AddError(errTimeout)
+			}
+		})
+		if err != nil {
+			t.Fatalf("unable to register query callback: %v", err)
+		}
+		err = db.Callback().Update().After("gorm:update").Register("test:fail_token", func(tx *gorm.DB) {
+			if _, ok := tx.Statement.Dest.(*models.Token); ok && failing == "update" {
+				tx.AddError(errTimeout)
+				return
+			}
+			tx.RowsAffected = 1
+		})
+		if err != nil {
+			t.Fatalf("unable to register update callback: %v", err)
+		}
+		var statements []string
+		err = db.Callback().Raw().After("gorm:raw").Register("test:statements", func(tx *gorm.DB) {
+			statements = append(statements, tx.Statement.SQL.String())
+		})
+		if err != nil {
+			t.Fatalf("unable to register raw callback: %v", err)
+		}
+		observer := &recordingBalanceObserver{}
+		protocol := &CFT20{chainID: "cosmoshub-4", db: db, balanceObserver: observer, amountPrecision: AmountPrecisionReject}
+
+		protocolURN := parseTestURN(t, "urn:cft20:cosmoshub-4@v1;buy$tic=TEST,ord=5")
+		err = protocol.Process(models.Transaction{ID: 1, Height: 100}, protocolURN, testTransaction(t, buyer, seller, 2000000))
+		if err != nil {
+			t.Fatalf("expected buy to succeed when the volume %s fails, got %v", failing, err)
+		}
+		if pool.Commits != 1 || pool.Rollbacks != 0 {
+			t.Errorf("expected the buy to be committed when the volume %s fails, got %d commits and %d rollbacks", failing, pool.Commits, pool.Rollbacks)
+		}
+		// On Postgres a failed statement aborts the transaction unless it
+		// is rolled back to a savepoint
+		rolledBack := 0
+		for _, statement := range statements {
+			if strings.HasPrefix(statement, "ROLLBACK TO SAVEPOINT") {
+				rolledBack++
+			}
+		}
+		if rolledBack != 1 {
+			t.Errorf("expected the volume %s to be rolled back to a savepoint, got %v", failing, statements)
+		}
+		if len(observer.changes) != 1 || observer.changes[0] != (balanceChange{buyer, "0", "1000000"}) {
+			t.Errorf("expected the buyer to receive the tokens, got %+v", observer.changes)
+		}
+	}
+}
+
+func TestProcessFrozenToken(t *testing.T) {
+	seller := "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95"
+	buyer := "cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"
+	tests := []struct {
+		operation  string
+		sender     string
+		amountSent uint64
+	}{
+		{"mint$tic=TEST", seller, 0},
+		{"transfer$tic=TEST,amt=1,dst=" + buyer, seller, 0},
+		{"list$tic=TEST,amt=1,ppt=2", seller, 0},
+		{"buy$tic=TEST,ord=5", buyer, 2000000},
+		{"delist$tic=TEST,ord=5", seller, 0},
+	}
+
+	for _, frozen := range []bool{false, true} {
+		for _, test := range tests {
+			var statements []string
+			db := newRowsAffectedDB(t, 1, &statements)
+			dbtest.StubQueries(t, db,
+				&models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST", Decimals: 6, MaxSupply: models.NewAmount(21000000000000), PerMintLimit: models.NewAmount(1000000), IsFrozen: frozen},
+				&models.TokenHolder{ID: 1, ChainID: "cosmoshub-4", TokenID: 1, Address: seller, Amount: models.NewAmount(100000000)},
+				&models.TokenOpenPosition{ID: 5, ChainID: "cosmoshub-4", TokenID: 1, SellerAddress: seller, Amount: models.NewAmount(1000000), Total: 2000000},
+				&models.Status{ChainID: "cosmoshub-4", BaseTokenUSD: 10},
+			)
+			protocol := &CFT20{chainID: "cosmoshub-4", db: db, balanceObserver: noopBalanceObserver{}, amountPrecision: AmountPrecisionReject, amountMaxDecimals: defaultAmountMaxDecimals}
+
+			protocolURN := parseTestURN(t, "urn:cft20:cosmoshub-4@v1;"+test.operation)
+			transactionModel := models.Transaction{ID: 1, Height: 100, DateCreated: time.Unix(1700000000, 0)}
+			err := protocol.Process(transactionModel, protocolURN, testTransaction(t, test.sender, seller, test.amountSent))
+			if !frozen && err != nil {
+				t.Errorf("expected '%s' to succeed, got %v", test.operation, err)
+			}
+			if frozen && (!errors.Is(err, ErrInvalidOperation) || !strings.Contains(err.Error(), "is frozen")) {
+				t.Errorf("expected '%s' to be rejected for a frozen token, got %v", test.operation, err)
+			}
+		}
+	}
+}
+
+func TestProcessTransferToSelf(t *testing.T) {
+	db := dbtest.OpenPostgres(t)
+	sender := "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95"
+	tokenModel := createTestToken(t, db, "TESTSELF")
+	err := db.Create(&models.TokenHolder{ChainID: "cosmoshub-4", TokenID: tokenModel.ID, Address: sender, Amount: models.NewAmount(5000000), DateUpdated: tokenModel.DateCreated}).Error
+	if err != nil {
+		t.Fatalf("unable to create holder: %v", err)
+	}
+	protocol := &CFT20{chainID: "cosmoshub-4", db: db, balanceObserver: noopBalanceObserver{}, amountPrecision: AmountPrecisionReject, amountMaxDecimals: defaultAmountMaxDecimals}
+
+	// The destination is the sender's address in upper case
+	protocolURN := parseTestURN(t, "urn:cft20:cosmoshub-4@v1;transfer$tic=TESTSELF,amt=2,dst="+strings.ToUpper(sender))
+	transactionModel := createTestTransaction(t, db, 2)
+	err = protocol.Process(transactionModel, protocolURN, testTransaction(t, sender, sender, 0))
+	if err != nil {
+		t.Fatalf("expected transfer to self to succeed, got %v", err)
+	}
+
+	var holders []models.TokenHolder
+	err = db.Where("token_id = ? AND LOWER(address) = ?", tokenModel.ID, sender).Find(&holders).Error
+	if err != nil {
+		t.Fatalf("unable to find holders: %v", err)
+	}
+	if len(holders) != 1 {
+		t.Fatalf("expected a single holder row, got %d", len(holders))
+	}
+	if holders[0].Address != sender || holders[0].Amount.String() != "5000000" {
+		t.Errorf("expected %s to keep 5000000, got %s with %s", sender, holders[0].Address, holders[0].Amount)
+	}
+}
+
+func TestPruneZeroHolders(t *testing.T) {
+	db := dbtest.OpenPostgres(t)
+	tokenModel := createTestToken(t, db, "TESTPRUNE")
+	otherTokenModel := createTestToken(t, db, "TESTPRUNE2")
+
+	// The holders use their own chain so rows already in the database are
+	// left alone
+	chainID := "prunetest-1"
+	holders := map[string]uint64{
+		"cosmos1zero":             0,
+		"cosmos1nonzero":          10,
+		"cosmos1openlisting":      0,
+		"cosmos1filledlisting":    0,
+		"cosmos1otherlisting":     0,
+		"cosmos1cancelledlisting": 0,
+	}
+	for address, amount := range holders {
+		err := db.Create(&models.TokenHolder{ChainID: chainID, TokenID: tokenModel.ID, Address: address, Amount: models.NewAmount(amount), DateUpdated: tokenModel.DateCreated}).Error
+		if err != nil {
+			t.Fatalf("unable to create holder: %v", err)
+		}
+	}
+
+	listings := []struct {
+		seller    string
+		tokenID   uint64
+		filled    bool
+		cancelled bool
+	}{
+		{"cosmos1openlisting", tokenModel.ID, false, false},
+		{"cosmos1filledlisting", tokenModel.ID, true, false},
+		{"cosmos1cancelledlisting", tokenModel.ID, false, true},
+		{"cosmos1otherlisting", otherTokenModel.ID, false, false},
+	}
+	for _, listing := range listings {
+		transactionModel := createTestTransaction(t, db, 2)
+		listingModel := models.MarketplaceListing{
+			ChainID:        "cosmoshub-4",
+			TransactionID:  transactionModel.ID,
+			SellerAddress:  listing.seller,
+			Total:          100,
+			DepositTotal:   10,
+			DepositTimeout: 100,
+			IsFilled:       listing.filled,
+			IsCancelled:    listing.cancelled,
+			DateCreated:    transactionModel.DateCreated,
+		}
+		if err := db.Create(&listingModel).Error; err != nil {
+			t.Fatalf("unable to create listing: %v", err)
+		}
+		err := db.Create(&models.MarketplaceCFT20Detail{ListingID: listingModel.ID, TokenID: listing.tokenID, Amount: models.NewAmount(10), PPT: 10, DateCreated: transactionModel.DateCreated}).Error
+		if err != nil {
+			t.Fatalf("unable to create listing detail: %v", err)
+		}
+	}
+
+	protocol := &CFT20{chainID: chainID, db: db}
+	pruned, err := protocol.PruneZeroHolders(chainID)
+	if err != nil {
+		t.Fatalf("expected prune to succeed, got %v", err)
+	}
+	if pruned != 4 {
+		t.Errorf("expected 4 holders to be pruned, got %d", pruned)
+	}
+
+	var remaining []string
+	err = db.Model(&models.TokenHolder{}).Where("chain_id = ?", chainID).Order("address").Pluck("address", &remaining).Error
+	if err != nil {
+		t.Fatalf("unable to find holders: %v", err)
+	}
+	// Only a non-zero balance or an open listing of the same token keeps a
+	// holder
+	expected := []string{"cosmos1nonzero", "cosmos1openlisting"}
+	if !reflect.DeepEqual(remaining, expected) {
+		t.Errorf("expected holders %v to remain, got %v", expected, remaining)
+	}
+}
+
+func TestTokenAddressBalanceHistory(t *testing.T) {
+	db := dbtest.OpenPostgres(t)
+	seller := "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95"
+	buyer := "cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"
+	err := db.Where(models.Status{ChainID: "cosmoshub-4"}).Attrs(models.Status{BaseToken: "ATOM", BaseTokenUSD: 10, DateUpdated: time.Unix(1700000000, 0)}).FirstOrCreate(&models.Status{}).Error
+	if err != nil {
+		t.Fatalf("unable to create status: %v", err)
+	}
+	protocol := &CFT20{
+		chainID:           "cosmoshub-4",
+		db:                db,
+		balanceObserver:   noopBalanceObserver{},
+		amountPrecision:   AmountPrecisionReject,
+		amountMaxDecimals: defaultAmountMaxDecimals,
+		nameMinLength:     1,
+		nameMaxLength:     32,
+		tickerMinLength:   1,
+		tickerMaxLength:   10,
+		decimalsMaxValue:  6,
+		maxSupplyMaxValue: 10000000000000000000,
+	}
+	process := func(height uint64, operation string, rawTransaction types.RawTransaction) {
+		t.Helper()
+		transactionModel := createTestTransaction(t, db, height)
+		err := protocol.Process(transactionModel, parseTestURN(t, "urn:cft20:cosmoshub-4@v1;"+operation), rawTransaction)
+		if err != nil {
+			t.Fatalf("expected '%s' at height %d to succeed, got %v", operation, height, err)
+		}
+	}
+
+	process(10, "deploy$nam=Balance,tic=TESTBAL,sup=1000000,dec=6,lim=1000,opn=0", testTransaction(t, seller, seller, 0))
+	// Two mints at the same height collapse into a single point
+	process(11, "mint$tic=TESTBAL", testTransaction(t, seller, seller, 0))
+	process(11, "mint$tic=TESTBAL", testTransaction(t, seller, seller, 0))
+	process(12, "mint$tic=TESTBAL", testTransaction(t, buyer, buyer, 0))
+	process(13, "transfer$tic=TESTBAL,amt=300,dst="+buyer, testTransaction(t, seller, seller, 0))
+	process(14, "list$tic=TESTBAL,amt=500,ppt=0.1", testTransaction(t, seller, seller, 0))
+
+	var tokenModel models.Token
+	if err := db.Where("ticker = ?", "TESTBAL").First(&tokenModel).Error; err != nil {
+		t.Fatalf("unable to find token: %v", err)
+	}
+	var positionModel models.TokenOpenPosition
+	if err := db.Where("token_id = ?", tokenModel.ID).First(&positionModel).Error; err != nil {
+		t.Fatalf("unable to find position: %v", err)
+	}
+	process(15, fmt.Sprintf("buy$tic=TESTBAL,ord=%d", positionModel.ID), testTransaction(t, buyer, seller, positionModel.Total))
+
+	type point struct {
+		Address string
+		Height  uint64
+		Balance string
+	}
+	var points []point
+	err = db.Table("token_address_balance_history").Select("address, height, balance").Where("token_id = ?", tokenModel.ID).Order("address, height").Scan(&points).Error
+	if err != nil {
+		t.Fatalf("unable to read balance history: %v", err)
+	}
+
+	// Balances accounted by hand, the ticker that sends mints and the
+	// marketplace holding the listed tokens have none
+	expected := []point{
+		{buyer, 12, "1000000000"},
+		{buyer, 13, "1300000000"},
+		{buyer, 15, "1800000000"},
+		{seller, 11, "2000000000"},
+		{seller, 13, "1700000000"},
+		{seller, 14, "1200000000"},
+	}
+	if !reflect.DeepEqual(points, expected) {
+		t.Fatalf("expected balance history %v, got %v", expected, points)
+	}
+
+	// The last point of each address is its current balance
+	for _, address := range []string{buyer, seller} {
+		var holderModel models.TokenHolder
+		if err := db.Where("token_id = ? AND address = ?", tokenModel.ID, address).First(&holderModel).Error; err != nil {
+			t.Fatalf("unable to find holder %s: %v", address, err)
+		}
+		var last point
+		for _, p := range points {
+			if p.Address == address {
+				last = p
+			}
+		}
+		if last.Balance != holderModel.Amount.String() {
+			t.Errorf("expected last balance of %s to be %s, got %s", address, holderModel.Amount, last.Balance)
+		}
+	}
+}
+
+func TestTopHoldersQuery(t *testing.T) {
+	var queries []string
+	db := dbtest.Open(t)
+	err := db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		callbacks.BuildQuerySQL(tx)
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("unable to replace query callback: %v", err)
+	}
+	protocol := &CFT20{db: db}
+
+	_, err = protocol.TopHolders("cosmoshub-4", 1, 3, 6)
+	if err != nil {
+		t.Fatalf("expected top holders query to succeed, got %v", err)
+	}
+	if len(queries) != 1 {
+		t.Fatalf("expected a single query, got %v", queries)
+	}
+	for _, expected := range []string{"amount > 0", "ORDER BY amount DESC, id ASC", "LIMIT 3", "OFFSET 6"} {
+		if !strings.Contains(queries[0], expected) {
+			t.Errorf("expected query to contain '%s', got %s", expected, queries[0])
+		}
+	}
+
+	// Pages must be bounded
+	for _, limit := range []int{0, -1, maxTopHolders + 1} {
+		if _, err := protocol.TopHolders("cosmoshub-4", 1, limit, 0); err == nil {
+			t.Errorf("expected limit %d to be rejected", limit)
+		}
+	}
+	if _, err := protocol.TopHolders("cosmoshub-4", 1, 10, -1); err == nil {
+		t.Errorf("expected a negative offset to be rejected")
+	}
+}
+
+func TestTopHolders(t *testing.T) {
+	db := dbtest.OpenPostgres(t)
+	tokenModel := createTestToken(t, db, "TESTTOP")
+	otherTokenModel := createTestToken(t, db, "TESTTOP2")
+
+	// Holders are created in this order, so equal balances keep it
+	holders := []struct {
+		address string
+		tokenID uint64
+		amount  uint64
+	}{
+		{"cosmos1small", tokenModel.ID, 5},
+		{"cosmos1tiedfirst", tokenModel.ID, 20},
+		{"cosmos1largest", tokenModel.ID, 30},
+		{"cosmos1tiedsecond", tokenModel.ID, 20},
+		{"cosmos1empty", tokenModel.ID, 0},
+		{"cosmos1othertoken", otherTokenModel.ID, 100},
+		{"cosmos1smallest", tokenModel.ID, 1},
+	}
+	for _, holder := range holders {
+		err := db.Create(&models.TokenHolder{ChainID: "cosmoshub-4", TokenID: holder.tokenID, Address: holder.address, Amount: models.NewAmount(holder.amount), DateUpdated: tokenModel.DateCreated}).Error
+		if err != nil {
+			t.Fatalf("unable to create holder: %v", err)
+		}
+	}
+	protocol := &CFT20{chainID: "cosmoshub-4", db: db}
+
+	tests := []struct {
+		limit    int
+		offset   int
+		expected []string
+	}{
+		{10, 0, []string{"cosmos1largest", "cosmos1tiedfirst", "cosmos1tiedsecond", "cosmos1small", "cosmos1smallest"}},
+		{2, 0, []string{"cosmos1largest", "cosmos1tiedfirst"}},
+		{2, 2, []string{"cosmos1tiedsecond", "cosmos1small"}},
+		{2, 4, []string{"cosmos1smallest"}},
+		{2, 6, nil},
+	}
+	for _, test := range tests {
+		page, err := protocol.TopHolders("cosmoshub-4", tokenModel.ID, test.limit, test.offset)
+		if err != nil {
+			t.Fatalf("expected top holders to succeed, got %v", err)
+		}
+		var addresses []string
+		for _, holder := range page {
+			addresses = append(addresses, holder.Address)
+		}
+		if !reflect.DeepEqual(addresses, test.expected) {
+			t.Errorf("limit %d offset %d: expected %v, got %v", test.limit, test.offset, test.expected, addresses)
+		}
+	}
+}
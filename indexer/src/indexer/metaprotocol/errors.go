@@ -0,0 +1,51 @@
+package metaprotocol
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidOperation matches errors for operations that break the
+	// metaprotocol rules. Processing them again gives the same result
+	ErrInvalidOperation = errors.New("invalid operation")
+	// ErrBalanceChanged matches errors for balances that were changed by
+	// another writer while an operation was processed
+	ErrBalanceChanged = errors.New("balance was modified concurrently")
+)
+
+// invalidOperationError is an operation error that matches
+// ErrInvalidOperation without changing the message stored for the
+// transaction
+type invalidOperationError struct {
+	message string
+	// err is the error that made the operation invalid, if any
+	err error
+}
+
+func (err invalidOperationError) Error() string {
+	return err.message
+}
+
+func (err invalidOperationError) Is(target error) bool {
+	return target == ErrInvalidOperation
+}
+
+func (err invalidOperationError) Unwrap() error {
+	return err.err
+}
+
+// invalidOperationf formats an error for an operation that breaks the
+// metaprotocol rules
+func invalidOperationf(format string, args ...interface{}) error {
+	return invalidOperationError{message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidOperation marks err, such as a failure to parse user input, as an
+// operation that breaks the metaprotocol rules. The message is unchanged
+func InvalidOperation(err error) error {
+	if err == nil {
+		return nil
+	}
+	return invalidOperationError{message: err.Error(), err: err}
+}
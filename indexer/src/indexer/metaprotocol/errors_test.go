@@ -0,0 +1,44 @@
+package metaprotocol
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestInvalidOperationError(t *testing.T) {
+	err := invalidOperationf("token with ticker '%s' is frozen", "TEST")
+	if err.Error() != "token with ticker 'TEST' is frozen" {
+		t.Errorf("expected message to be unchanged, got '%s'", err)
+	}
+	if !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("expected error to match ErrInvalidOperation")
+	}
+
+	// Operation errors keep matching when they are wrapped
+	wrapped := fmt.Errorf("unable to parse amount '%w'", err)
+	if !errors.Is(wrapped, ErrInvalidOperation) {
+		t.Errorf("expected wrapped error to match ErrInvalidOperation")
+	}
+	if errors.Is(errors.New("connection reset"), ErrInvalidOperation) {
+		t.Errorf("expected other errors not to match ErrInvalidOperation")
+	}
+}
+
+func TestInvalidOperation(t *testing.T) {
+	_, parseErr := strconv.ParseUint("abc", 10, 64)
+	err := InvalidOperation(parseErr)
+	if err.Error() != parseErr.Error() {
+		t.Errorf("expected message to be unchanged, got '%s'", err)
+	}
+	if !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("expected error to match ErrInvalidOperation")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected the original error to be kept")
+	}
+	if InvalidOperation(nil) != nil {
+		t.Errorf("expected no error to stay nil")
+	}
+}
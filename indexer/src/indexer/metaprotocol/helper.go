@@ -2,9 +2,16 @@ package metaprotocol
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
 	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/types"
+	"gorm.io/gorm"
 )
 
 type TokensTransferKind int
@@ -25,12 +32,12 @@ func GetBaseTokensSent(rawTransaction types.RawTransaction, kind TokensTransferK
 	for _, v := range rawTransaction.Body.Messages {
 		if v.Type == "/cosmos.bank.v1beta1.MsgSend" {
 			if v.Amount[0].Denom != "uatom" {
-				return 0, fmt.Errorf("incorrect denom sent, got %s, expected uatom", v.Amount[0].Denom)
+				return 0, invalidOperationf("incorrect denom sent, got %s, expected uatom", v.Amount[0].Denom)
 			}
 
 			amountSent, err = strconv.ParseUint(v.Amount[0].Amount, 10, 64)
 			if err != nil {
-				return 0, err
+				return 0, InvalidOperation(err)
 			}
 			break
 		}
@@ -46,21 +53,265 @@ func GetBaseTokensSentIBC(rawTransaction types.RawTransaction) (uint64, error) {
 	for _, v := range rawTransaction.Body.Messages {
 		if v.Type == "/ibc.applications.transfer.v1.MsgTransfer" {
 			if v.Token.Denom != "uatom" {
-				return 0, fmt.Errorf("incorrect denom sent, got %s, expected uatom", v.Amount[0].Denom)
+				return 0, invalidOperationf("incorrect denom sent, got %s, expected uatom", v.Amount[0].Denom)
 			}
 			if v.SourceChannel != "channel-569" {
-				return 0, fmt.Errorf("incorrect IBC channel, got %s, expected channel-569", v.SourceChannel)
+				return 0, invalidOperationf("incorrect IBC channel, got %s, expected channel-569", v.SourceChannel)
 			}
 			if v.Receiver != "neutron1unc0549k2f0d7mjjyfm94fuz2x53wrx3px0pr55va27grdgmspcqgzfr8p" {
-				return 0, fmt.Errorf("incorrect IBC receiver, got %s, expected neutron1unc0549k2f0d7mjjyfm94fuz2x53wrx3px0pr55va27grdgmspcqgzfr8p", v.Receiver)
+				return 0, invalidOperationf("incorrect IBC receiver, got %s, expected neutron1unc0549k2f0d7mjjyfm94fuz2x53wrx3px0pr55va27grdgmspcqgzfr8p", v.Receiver)
 			}
 
 			amountSent, err = strconv.ParseUint(v.Token.Amount, 10, 64)
 			if err != nil {
-				return 0, err
+				return 0, InvalidOperation(err)
 			}
 			return amountSent, nil
 		}
 	}
-	return 0, fmt.Errorf("invalid fee attached to transaction")
+	return 0, invalidOperationf("invalid fee attached to transaction")
+}
+
+// defaultAmountMaxDecimals is the maximum number of fractional digits
+// accepted in an amount unless AMOUNT_MAX_DECIMALS is set
+const defaultAmountMaxDecimals = 18
+
+// AmountPrecision decides what happens to amounts with more fractional
+// digits than the token's decimals
+type AmountPrecision string
+
+const (
+	// AmountPrecisionReject rejects over-precise amounts
+	AmountPrecisionReject AmountPrecision = "reject"
+	// AmountPrecisionTruncate drops the fractional digits beyond the token's
+	// decimals
+	AmountPrecisionTruncate AmountPrecision = "truncate"
+)
+
+// ParseAmountPrecision parses a configured precision mode
+func ParseAmountPrecision(value string) (AmountPrecision, error) {
+	precision := AmountPrecision(strings.ToLower(strings.TrimSpace(value)))
+	switch precision {
+	case AmountPrecisionReject, AmountPrecisionTruncate:
+		return precision, nil
+	}
+	return "", fmt.Errorf("invalid amount precision '%s'", value)
+}
+
+// tokenAmountPrecision returns the precision mode for tokenModel, which may
+// override the deployment's mode. Operations on a token with an invalid mode
+// are rejected
+func tokenAmountPrecision(deployment AmountPrecision, tokenModel models.Token) (AmountPrecision, error) {
+	if tokenModel.AmountPrecision == "" {
+		return deployment, nil
+	}
+	precision, err := ParseAmountPrecision(tokenModel.AmountPrecision)
+	if err != nil {
+		return "", invalidOperationf("token '%s' has an invalid amount precision '%s'", tokenModel.Ticker, tokenModel.AmountPrecision)
+	}
+	return precision, nil
+}
+
+// tokenMaxAmount returns the largest amount of tokenModel, in base units,
+// that can be moved at once. No holder can have more than the max supply
+func tokenMaxAmount(tokenModel models.Token) *big.Int {
+	return tokenModel.MaxSupply.BigInt()
+}
+
+// ParseAmount parses a decimal amount string, such as "1.5", and scales it
+// by the given number of decimals to return the amount in base units.
+// Amounts with more fractional digits than decimals are rejected instead of
+// being rounded, tokens with 0 decimals only accept whole amounts
+func ParseAmount(amountString string, decimals uint64) (models.Amount, error) {
+	return parseAmount(amountString, decimals, AmountPrecisionReject, defaultAmountMaxDecimals)
+}
+
+// parseAmount implements ParseAmount, truncating fractional digits beyond
+// decimals instead of rejecting them when precision is truncate. Amounts with
+// more than maxDecimals fractional digits are always rejected, independent of
+// the token's decimals, to bound pathological inputs
+func parseAmount(amountString string, decimals uint64, precision AmountPrecision, maxDecimals uint64) (models.Amount, error) {
+	wholePart, fractionalPart, hasFraction := strings.Cut(amountString, ".")
+	if !isDigits(wholePart) {
+		return models.Amount{}, invalidOperationf("invalid amount '%s'", amountString)
+	}
+	if hasFraction {
+		if !isDigits(fractionalPart) {
+			return models.Amount{}, invalidOperationf("invalid amount '%s'", amountString)
+		}
+		if uint64(len(fractionalPart)) > maxDecimals {
+			return models.Amount{}, invalidOperationf("amount '%s' has more than the maximum of %d decimals", amountString, maxDecimals)
+		}
+		if uint64(len(fractionalPart)) > decimals {
+			if precision != AmountPrecisionTruncate {
+				if decimals == 0 {
+					return models.Amount{}, invalidOperationf("amount '%s' may not have a fractional part", amountString)
+				}
+				return models.Amount{}, invalidOperationf("amount '%s' has more than %d decimals", amountString, decimals)
+			}
+			fractionalPart = fractionalPart[:decimals]
+		}
+	}
+
+	// Pad the fractional part to the token's decimals and parse the digits
+	// as a single integer in base units
+	digits := wholePart + fractionalPart + strings.Repeat("0", int(decimals)-len(fractionalPart))
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return models.Amount{}, invalidOperationf("invalid amount '%s'", amountString)
+	}
+	return models.NewAmountFromBigInt(amount), nil
+}
+
+// ValidateAndScaleAmount parses amountString with ParseAmount and checks that
+// the amount in base units is within min and max, inclusive. A nil min or
+// max leaves that side unbounded
+func ValidateAndScaleAmount(amountString string, decimals uint, min *big.Int, max *big.Int) (models.Amount, error) {
+	return ValidateAndScaleAmountWithPrecision(amountString, decimals, min, max, AmountPrecisionReject, defaultAmountMaxDecimals)
+}
+
+// ValidateAndScaleAmountWithPrecision is ValidateAndScaleAmount with a choice
+// of rejecting or truncating over-precise amounts and of the maximum number
+// of fractional digits accepted. Bounds are checked after truncation
+func ValidateAndScaleAmountWithPrecision(amountString string, decimals uint, min *big.Int, max *big.Int, precision AmountPrecision, maxDecimals uint64) (models.Amount, error) {
+	amount, err := parseAmount(amountString, uint64(decimals), precision, maxDecimals)
+	if err != nil {
+		return models.Amount{}, err
+	}
+	baseAmount := amount.BigInt()
+	if min != nil && baseAmount.Cmp(min) < 0 {
+		return models.Amount{}, invalidOperationf("amount '%s' is less than the minimum of %s base units", amountString, min)
+	}
+	if max != nil && baseAmount.Cmp(max) > 0 {
+		return models.Amount{}, invalidOperationf("amount '%s' is more than the maximum of %s base units", amountString, max)
+	}
+	return amount, nil
+}
+
+// isDigits returns true if value is a non-empty string of only 0-9
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeAddress returns the canonical lowercase bech32 form of address.
+// Addresses are used as holder and owner keys, so differently cased
+// representations of the same address must map to a single value
+func NormalizeAddress(address string) (string, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+	prefix, data, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return "", InvalidOperation(fmt.Errorf("invalid address '%s': %w", address, err))
+	}
+	return bech32.ConvertAndEncode(prefix, data)
+}
+
+// normalizeTicker trims ticker and upper-cases it, unless tickers are
+// case-sensitive
+func normalizeTicker(ticker string, caseSensitive bool) string {
+	ticker = strings.TrimSpace(ticker)
+	if caseSensitive {
+		return ticker
+	}
+	return strings.ToUpper(ticker)
+}
+
+// saveOptional stores a record the operation doesn't depend on, such as
+// history. It runs in a savepoint so a failed save leaves the surrounding
+// transaction usable
+func saveOptional(db *gorm.DB, value interface{}) error {
+	return runOptional(db, func(tx *gorm.DB) error {
+		return tx.Save(value).Error
+	})
+}
+
+// runOptional runs statements the operation doesn't depend on, such as
+// updating token statistics, in a savepoint. If any of them fails its
+// changes are undone and the surrounding transaction stays usable
+func runOptional(db *gorm.DB, statements func(tx *gorm.DB) error) error {
+	return db.Transaction(statements)
+}
+
+// findToken looks up a token by ticker, or by the hash of its deploy
+// transaction when tokenHash is set. Tickers can collide across deployments,
+// the deploy hash can't. When resolving by hash the ticker must still match
+func findToken(db *gorm.DB, chainID string, ticker string, tokenHash string) (models.Token, error) {
+	var tokenModel models.Token
+	if tokenHash == "" {
+		// Retired tokens are included so that they can be reported as such
+		result := db.Unscoped().Where("chain_id = ? AND ticker = ?", chainID, ticker).First(&tokenModel)
+		if result.Error != nil {
+			return tokenModel, invalidOperationf("token with ticker '%s' doesn't exist", ticker)
+		}
+		return tokenModel, checkTokenUsable(tokenModel, chainID)
+	}
+
+	tokenHash = strings.ToUpper(strings.TrimSpace(tokenHash))
+	result := db.Unscoped().Where("chain_id = ? AND transaction_id = (SELECT id FROM transaction WHERE hash = ?)", chainID, tokenHash).First(&tokenModel)
+	if result.Error != nil {
+		return tokenModel, invalidOperationf("token with id '%s' doesn't exist", tokenHash)
+	}
+	err := checkTokenUsable(tokenModel, chainID)
+	if err != nil {
+		return tokenModel, err
+	}
+	return tokenModel, checkTokenTicker(tokenModel, ticker)
+}
+
+// checkTokenUsable returns an error if tokenModel doesn't belong to chainID
+// or has been retired
+func checkTokenUsable(tokenModel models.Token, chainID string) error {
+	err := checkTokenChain(tokenModel, chainID)
+	if err != nil {
+		return err
+	}
+	if tokenModel.IsRetired() {
+		return invalidOperationf("token with ticker '%s' has been retired", tokenModel.Ticker)
+	}
+	return nil
+}
+
+// checkTokenChain returns an error if tokenModel doesn't belong to chainID.
+// The lookup already filters on the chain, this guards against that filter
+// ever being loosened
+func checkTokenChain(tokenModel models.Token, chainID string) error {
+	if tokenModel.ChainID != chainID {
+		return invalidOperationf("token '%s' belongs to chain '%s', not '%s'", tokenModel.Ticker, tokenModel.ChainID, chainID)
+	}
+	return nil
+}
+
+// checkTokenTicker returns an error if tokenModel doesn't have ticker
+func checkTokenTicker(tokenModel models.Token, ticker string) error {
+	if tokenModel.Ticker != ticker {
+		return invalidOperationf("token has ticker '%s', not '%s'", tokenModel.Ticker, ticker)
+	}
+	return nil
+}
+
+// toUint64 converts value to a uint64 and returns an error instead of
+// truncating when value is negative, not a number or too large
+func toUint64(value float64) (uint64, error) {
+	// float64(math.MaxUint64) rounds up to 2^64, which doesn't fit
+	if math.IsNaN(value) || value < 0 || value >= math.MaxUint64 {
+		return 0, invalidOperationf("%g is out of range", value)
+	}
+	return uint64(value), nil
+}
+
+// unixTimestamp returns t as a unix timestamp and returns an error for times
+// before the epoch, which can't be represented as a uint64
+func unixTimestamp(t time.Time) (uint64, error) {
+	timestamp := t.Unix()
+	if timestamp < 0 {
+		return 0, invalidOperationf("timestamp %d is before the epoch", timestamp)
+	}
+	return uint64(timestamp), nil
 }
@@ -0,0 +1,401 @@
+package metaprotocol
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/internal/dbtest"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint64
+		expected string
+		valid    bool
+	}{
+		{"100", 0, "100", true},
+		{"100.5", 0, "0", false},
+		{"100.", 0, "0", false},
+		{"100.0", 0, "0", false},
+		{"1", 6, "1000000", true},
+		{"1.5", 6, "1500000", true},
+		{"0.000001", 6, "1", true},
+		{"0.0000001", 6, "0", false},
+		{"1.", 6, "0", false},
+		{".5", 6, "0", false},
+		{"-1", 6, "0", false},
+		{"1e6", 6, "0", false},
+		{"", 6, "0", false},
+		{"18446744073709551615", 0, "18446744073709551615", true},
+		{"18446744073709551616", 0, "18446744073709551616", true},
+		{"18446744073709.551616", 6, "18446744073709551616", true},
+		{"340282366920938463463374607431768211456", 0, "340282366920938463463374607431768211456", true},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseAmount(test.amount, test.decimals)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' with %d decimals to parse, got %v", test.amount, test.decimals, err)
+			continue
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' with %d decimals to fail, got %s", test.amount, test.decimals, amount)
+			continue
+		}
+		if amount.String() != test.expected {
+			t.Errorf("expected '%s' with %d decimals to be %s, got %s", test.amount, test.decimals, test.expected, amount)
+		}
+	}
+}
+
+func TestValidateAndScaleAmount(t *testing.T) {
+	one := big.NewInt(1)
+	maxSupply := new(big.Int).SetUint64(10000000000000000000)
+	tests := []struct {
+		name     string
+		amount   string
+		decimals uint
+		min      *big.Int
+		max      *big.Int
+		expected string
+		valid    bool
+	}{
+		{"whole amount", "100", 6, one, nil, "100000000", true},
+		{"fractional amount", "0.5", 6, one, nil, "500000", true},
+		{"smallest unit", "0.000001", 6, one, nil, "1", true},
+		{"zero below minimum", "0", 6, one, nil, "0", false},
+		{"zero fraction below minimum", "0.000000", 6, one, nil, "0", false},
+		{"zero without minimum", "0", 6, nil, nil, "0", true},
+		{"too many decimals", "0.0000001", 6, one, nil, "0", false},
+		{"fraction on whole token", "1.5", 0, one, nil, "0", false},
+		{"negative", "-1", 6, one, nil, "0", false},
+		{"explicit plus sign", "+1", 6, one, nil, "0", false},
+		{"exponent", "1e6", 0, one, nil, "0", false},
+		{"whitespace", " 1", 6, one, nil, "0", false},
+		{"empty", "", 6, one, nil, "0", false},
+		{"missing whole part", ".5", 6, one, nil, "0", false},
+		{"at maximum", "10000000000000", 6, one, maxSupply, "10000000000000000000", true},
+		{"above maximum", "10000000000000.000001", 6, one, maxSupply, "0", false},
+		{"above uint64", "18446744073709551616", 0, one, nil, "18446744073709551616", true},
+		{"largest uint64", "18446744073709551615", 0, one, nil, "18446744073709551615", true},
+		{"minimum above one", "0.5", 6, big.NewInt(1000000), nil, "0", false},
+	}
+
+	for _, test := range tests {
+		amount, err := ValidateAndScaleAmount(test.amount, test.decimals, test.min, test.max)
+		if test.valid && err != nil {
+			t.Errorf("%s: expected '%s' to be valid, got %v", test.name, test.amount, err)
+			continue
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected '%s' to be invalid, got %s", test.name, test.amount, amount)
+			continue
+		}
+		if amount.String() != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, amount)
+		}
+	}
+}
+
+func TestValidateAndScaleAmountPrecision(t *testing.T) {
+	one := big.NewInt(1)
+
+	// Rejected by default
+	_, err := ValidateAndScaleAmountWithPrecision("1.1234567", 6, one, nil, AmountPrecisionReject, defaultAmountMaxDecimals)
+	if err == nil {
+		t.Errorf("expected over-precise amount to be rejected")
+	}
+
+	// Truncated to the token's decimals
+	amount, err := ValidateAndScaleAmountWithPrecision("1.1234567", 6, one, nil, AmountPrecisionTruncate, defaultAmountMaxDecimals)
+	if err != nil || amount.String() != "1123456" {
+		t.Errorf("expected over-precise amount to truncate to 1123456, got %s (%v)", amount, err)
+	}
+	amount, err = ValidateAndScaleAmountWithPrecision("1.9", 0, one, nil, AmountPrecisionTruncate, defaultAmountMaxDecimals)
+	if err != nil || amount.String() != "1" {
+		t.Errorf("expected fraction of a whole token to truncate to 1, got %s (%v)", amount, err)
+	}
+
+	// Bounds apply to the truncated amount
+	_, err = ValidateAndScaleAmountWithPrecision("0.0000001", 6, one, nil, AmountPrecisionTruncate, defaultAmountMaxDecimals)
+	if err == nil {
+		t.Errorf("expected amount truncated to zero to be below the minimum")
+	}
+}
+
+func TestTokenMaxAmount(t *testing.T) {
+	token := models.Token{Decimals: 6, MaxSupply: models.NewAmount(1000000)}
+	one := big.NewInt(1)
+
+	tests := []struct {
+		amount string
+		valid  bool
+	}{
+		{"1", true},
+		{"1.000001", false},
+		{"1000000000000", false},
+		{"18446744073709.551615", false},
+	}
+	for _, test := range tests {
+		_, err := ValidateAndScaleAmountWithPrecision(test.amount, uint(token.Decimals), one, tokenMaxAmount(token), AmountPrecisionReject, defaultAmountMaxDecimals)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' to be valid, got %s", test.amount, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' to exceed the max supply", test.amount)
+		}
+	}
+}
+
+func TestTokenAmountPrecision(t *testing.T) {
+	precision, err := tokenAmountPrecision(AmountPrecisionReject, models.Token{})
+	if err != nil || precision != AmountPrecisionReject {
+		t.Errorf("expected token without override to use the deployment mode, got '%s' (%v)", precision, err)
+	}
+	precision, err = tokenAmountPrecision(AmountPrecisionReject, models.Token{AmountPrecision: "truncate"})
+	if err != nil || precision != AmountPrecisionTruncate {
+		t.Errorf("expected token override to truncate, got '%s' (%v)", precision, err)
+	}
+	_, err = tokenAmountPrecision(AmountPrecisionReject, models.Token{AmountPrecision: "round"})
+	if !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("expected invalid token override to be an invalid operation, got %v", err)
+	}
+}
+
+func TestParseAmountPrecision(t *testing.T) {
+	precision, err := ParseAmountPrecision(" Truncate ")
+	if err != nil || precision != AmountPrecisionTruncate {
+		t.Errorf("expected 'truncate', got '%s' (%v)", precision, err)
+	}
+
+	// A bad configuration value is not a user error and must not be skipped
+	_, err = ParseAmountPrecision("round")
+	if err == nil || errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("expected invalid configuration to fail as a plain error, got %v", err)
+	}
+}
+
+func TestParseAmountMaxDecimals(t *testing.T) {
+	// At the limit
+	amount, err := parseAmount("1.0001", 8, AmountPrecisionReject, 4)
+	if err != nil || amount.String() != "100010000" {
+		t.Errorf("expected 4 decimals to parse as 100010000, got %s (%v)", amount, err)
+	}
+	// Beyond the limit, even though the token allows more decimals
+	_, err = parseAmount("1.00001", 8, AmountPrecisionReject, 4)
+	if err == nil {
+		t.Errorf("expected 5 decimals to exceed the maximum")
+	}
+	// Truncating doesn't lift the limit
+	_, err = parseAmount("1.00001", 2, AmountPrecisionTruncate, 4)
+	if err == nil {
+		t.Errorf("expected 5 decimals to exceed the maximum when truncating")
+	}
+	_, err = ParseAmount("1."+strings.Repeat("0", 100000), 8)
+	if err == nil {
+		t.Errorf("expected very long fraction to exceed the maximum")
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	lower, err := NormalizeAddress("cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95")
+	if err != nil {
+		t.Fatalf("expected address to normalize, got %v", err)
+	}
+	upper, err := NormalizeAddress(" COSMOS1FKWCEZ7YZJZ2GPZHESDSD3R5WP0W82CFTR4K95 ")
+	if err != nil {
+		t.Fatalf("expected address to normalize, got %v", err)
+	}
+	if lower != upper {
+		t.Errorf("expected differently cased addresses to match, got %s and %s", lower, upper)
+	}
+	if lower != "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95" {
+		t.Errorf("expected lowercase address, got %s", lower)
+	}
+
+	// Changing a single character breaks the checksum
+	_, err = NormalizeAddress("cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k96")
+	if err == nil {
+		t.Errorf("expected invalid checksum to fail")
+	}
+}
+
+// newTokenQueryDB returns a dry run database whose queries return token and
+// record the generated SQL
+func newTokenQueryDB(t *testing.T, token models.Token, queries *[]string) *gorm.DB {
+	db := dbtest.Open(t)
+	err := db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		callbacks.BuildQuerySQL(tx)
+		*queries = append(*queries, tx.Statement.SQL.String())
+		if tokenModel, ok := tx.Statement.Dest.(*models.Token); ok {
+			*tokenModel = token
+			tx.RowsAffected = 1
+		}
+	})
+	if err != nil {
+		t.Fatalf("unable to replace query callback: %v", err)
+	}
+	return db
+}
+
+func TestFindTokenByID(t *testing.T) {
+	var queries []string
+	db := newTokenQueryDB(t, models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST"}, &queries)
+
+	tokenModel, err := findToken(db, "cosmoshub-4", "TEST", "a1b2c3")
+	if err != nil {
+		t.Fatalf("expected token to resolve by id, got %v", err)
+	}
+	if tokenModel.ID != 1 {
+		t.Errorf("expected token 1, got %d", tokenModel.ID)
+	}
+	if len(queries) != 1 || !strings.Contains(queries[0], "transaction_id") {
+		t.Errorf("expected token to be resolved by deploy transaction, got %v", queries)
+	}
+
+	// The ticker in the operation must match the token the id resolves to
+	_, err = findToken(db, "cosmoshub-4", "OTHER", "a1b2c3")
+	if err == nil {
+		t.Errorf("expected ticker and id mismatch to fail")
+	}
+}
+
+func TestFindTokenTickerCase(t *testing.T) {
+	var queries []string
+	db := newTokenQueryDB(t, models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "ABC"}, &queries)
+
+	// By default tickers are upper-cased, so Abc refers to ABC
+	if ticker := normalizeTicker(" Abc ", false); ticker != "ABC" {
+		t.Errorf("expected ABC, got %s", ticker)
+	}
+	_, err := findToken(db, "cosmoshub-4", normalizeTicker("Abc", false), "a1b2c3")
+	if err != nil {
+		t.Errorf("expected Abc to match ABC, got %v", err)
+	}
+
+	// Case-sensitive tickers are kept as given, so Abc is a different token
+	if ticker := normalizeTicker(" Abc ", true); ticker != "Abc" {
+		t.Errorf("expected Abc, got %s", ticker)
+	}
+	_, err = findToken(db, "cosmoshub-4", normalizeTicker("Abc", true), "a1b2c3")
+	if err == nil {
+		t.Errorf("expected Abc not to match ABC")
+	}
+	_, err = findToken(db, "cosmoshub-4", normalizeTicker("ABC", true), "a1b2c3")
+	if err != nil {
+		t.Errorf("expected ABC to match ABC, got %v", err)
+	}
+
+	// Without a token id the normalized ticker is what gets looked up
+	var tickers []interface{}
+	err = db.Callback().Query().After("gorm:query").Register("test:ticker", func(tx *gorm.DB) {
+		tickers = append(tickers, tx.Statement.Vars[len(tx.Statement.Vars)-1])
+	})
+	if err != nil {
+		t.Fatalf("unable to register query callback: %v", err)
+	}
+	_, _ = findToken(db, "cosmoshub-4", normalizeTicker("Abc", false), "")
+	_, _ = findToken(db, "cosmoshub-4", normalizeTicker("Abc", true), "")
+	if len(tickers) != 2 || tickers[0] != "ABC" || tickers[1] != "Abc" {
+		t.Errorf("expected tickers ABC and Abc to be queried, got %v", tickers)
+	}
+}
+
+func TestFindTokenByTicker(t *testing.T) {
+	var queries []string
+	db := newTokenQueryDB(t, models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST"}, &queries)
+
+	_, err := findToken(db, "cosmoshub-4", "TEST", "")
+	if err != nil {
+		t.Fatalf("expected token to resolve by ticker, got %v", err)
+	}
+	if len(queries) != 1 || !strings.Contains(queries[0], "ticker") {
+		t.Errorf("expected token to be resolved by ticker, got %v", queries)
+	}
+}
+
+func TestFindTokenCrossChain(t *testing.T) {
+	var queries []string
+	db := newTokenQueryDB(t, models.Token{ID: 1, ChainID: "gaialocal-1", Ticker: "TEST"}, &queries)
+
+	_, err := findToken(db, "cosmoshub-4", "TEST", "")
+	if err == nil {
+		t.Errorf("expected token from another chain to be rejected by ticker")
+	}
+	_, err = findToken(db, "cosmoshub-4", "TEST", "a1b2c3")
+	if err == nil {
+		t.Errorf("expected token from another chain to be rejected by id")
+	}
+}
+
+func TestToUint64(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected uint64
+		valid    bool
+	}{
+		{0, 0, true},
+		{1e6, 1000000, true},
+		{1e19, 10000000000000000000, true},
+		{-1, 0, false},
+		{math.NaN(), 0, false},
+		{math.Inf(1), 0, false},
+		// 2^64 is the first float64 that doesn't fit
+		{18446744073709551616, 0, false},
+		{1e30, 0, false},
+	}
+
+	for _, test := range tests {
+		value, err := toUint64(test.value)
+		if test.valid && err != nil {
+			t.Errorf("expected %g to convert, got %v", test.value, err)
+			continue
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %g to fail, got %d", test.value, value)
+			continue
+		}
+		if value != test.expected {
+			t.Errorf("expected %g to be %d, got %d", test.value, test.expected, value)
+		}
+	}
+}
+
+func TestUnixTimestamp(t *testing.T) {
+	timestamp, err := unixTimestamp(time.Unix(1710000000, 0))
+	if err != nil || timestamp != 1710000000 {
+		t.Errorf("expected 1710000000, got %d (%v)", timestamp, err)
+	}
+	_, err = unixTimestamp(time.Unix(-1, 0))
+	if err == nil {
+		t.Errorf("expected a time before the epoch to fail")
+	}
+}
+
+func TestFindTokenRetired(t *testing.T) {
+	var queries []string
+	retired := models.Token{
+		ID:          1,
+		ChainID:     "cosmoshub-4",
+		Ticker:      "TEST",
+		DateDeleted: gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}
+	db := newTokenQueryDB(t, retired, &queries)
+
+	_, err := findToken(db, "cosmoshub-4", "TEST", "")
+	if err == nil || !strings.Contains(err.Error(), "retired") {
+		t.Errorf("expected retired token to be rejected as retired, got %v", err)
+	}
+	// The lookup must include retired tokens to report them
+	if len(queries) != 1 || strings.Contains(queries[0], "date_deleted") {
+		t.Errorf("expected lookup to include retired tokens, got %v", queries)
+	}
+}
@@ -3,6 +3,7 @@ package metaprotocol
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"mime"
@@ -27,6 +28,11 @@ type InscriptionConfig struct {
 	S3ID       string `envconfig:"S3_ID" required:"true"`
 	S3Secret   string `envconfig:"S3_SECRET" required:"true"`
 	S3Token    string `envconfig:"S3_TOKEN"`
+	// AllowedContentTypes restricts the MIME types that can be inscribed,
+	// entries can end in /* to allow a whole type. Empty allows everything
+	AllowedContentTypes []string `envconfig:"INSCRIPTION_ALLOWED_CONTENT_TYPES"`
+	// StrictURNKeys is shared with the CFT-20 processor
+	StrictURNKeys bool `envconfig:"STRICT_URN_KEYS" default:"false"`
 }
 
 type Inscription struct {
@@ -41,6 +47,10 @@ type Inscription struct {
 	s3Secret string
 	// s3Token is the S3 credentials token
 	s3Token string
+	// allowedContentTypes is the list of MIME types that can be inscribed
+	allowedContentTypes []string
+	// strictURNKeys rejects URNs with duplicate keys
+	strictURNKeys bool
 }
 
 func NewInscriptionProcessor(chainID string, db *gorm.DB) *Inscription {
@@ -53,14 +63,16 @@ func NewInscriptionProcessor(chainID string, db *gorm.DB) *Inscription {
 	}
 
 	return &Inscription{
-		chainID:    chainID,
-		db:         db,
-		s3Endpoint: config.S3Endpoint,
-		s3Region:   config.S3Region,
-		s3Bucket:   config.S3Bucket,
-		s3ID:       config.S3ID,
-		s3Secret:   config.S3Secret,
-		s3Token:    config.S3Token,
+		chainID:             chainID,
+		db:                  db,
+		s3Endpoint:          config.S3Endpoint,
+		s3Region:            config.S3Region,
+		s3Bucket:            config.S3Bucket,
+		s3ID:                config.S3ID,
+		s3Secret:            config.S3Secret,
+		s3Token:             config.S3Token,
+		allowedContentTypes: config.AllowedContentTypes,
+		strictURNKeys:       config.StrictURNKeys,
 	}
 }
 
@@ -68,8 +80,24 @@ func (protocol *Inscription) Name() string {
 	return "Inscription"
 }
 
+func (protocol *Inscription) Version() string {
+	return "1.0.0"
+}
+
+// Process applies the operation in protocolURN in a single database
+// transaction so a failure part way through leaves no partial changes behind
 func (protocol *Inscription) Process(transactionModel models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error {
+	return protocol.db.Transaction(func(tx *gorm.DB) error {
+		return protocol.process(tx, transactionModel, protocolURN, rawTransaction)
+	})
+}
+
+func (protocol *Inscription) process(db *gorm.DB, transactionModel models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error {
 	sender, err := rawTransaction.GetSenderAddress()
+	if err != nil {
+		return InvalidOperation(err)
+	}
+	sender, err = NormalizeAddress(sender)
 	if err != nil {
 		return err
 	}
@@ -77,17 +105,22 @@ func (protocol *Inscription) Process(transactionModel models.Transaction, protoc
 	// We need to parse the protocol specific string in SS, it contains
 	// {chainId}@{version};operation$h={unique hash of content}
 	// cosmoshub-4@v1beta;inscribe$h=c4749f95902411d1a45a033d8a6b3e6aa0de0a0028fe8737f66fed6834dce8bf
-	parsedURN, err := ParseProtocolString(protocolURN)
+	parsedURN, err := ParseProtocolString(protocolURN, protocol.strictURNKeys)
 	if err != nil {
 		return err
 	}
 
 	// TODO: Rework validation
 	if parsedURN.KeyValuePairs["h"] == "" {
-		return fmt.Errorf("missing content hash")
+		return invalidOperationf("missing content hash")
 	}
 	if parsedURN.ChainID != protocol.chainID {
-		return fmt.Errorf("invalid chain ID '%s'", parsedURN.ChainID)
+		return invalidOperationf("invalid chain ID '%s'", parsedURN.ChainID)
+	}
+
+	err = parsedURN.CheckExpiry(transactionModel.Height)
+	if err != nil {
+		return err
 	}
 
 	switch parsedURN.Operation {
@@ -100,7 +133,7 @@ func (protocol *Inscription) Process(transactionModel models.Transaction, protoc
 		for _, extension := range rawTransaction.Body.NonCriticalExtensionOptions {
 			msg, err = extension.UnmarshalData()
 			if err != nil {
-				return fmt.Errorf("unable to unmarshal extension data '%s'", err)
+				return InvalidOperation(fmt.Errorf("unable to unmarshal extension data '%w'", err))
 			}
 
 			// We only process the first extension option
@@ -109,18 +142,44 @@ func (protocol *Inscription) Process(transactionModel models.Transaction, protoc
 
 		inscriptionMetadata, err := msg.GetMetadata()
 		if err != nil {
-			return err
+			return InvalidOperation(err)
+		}
+
+		if !protocol.isContentTypeAllowed(inscriptionMetadata.Metadata.Mime) {
+			return invalidOperationf("content type '%s' is not allowed", inscriptionMetadata.Metadata.Mime)
+		}
+
+		// An inscription is added to a collection by naming the collection
+		// inscription as its parent, identified by its transaction hash
+		var collectionID *uint64
+		if inscriptionMetadata.Parent.Type == types.InscriptionTypeContentNFTCollection {
+			collection, err := findInscription(db, inscriptionMetadata.Parent.Identifier)
+			if err != nil {
+				return fmt.Errorf("invalid collection '%w'", err)
+			}
+			err = checkCollection(collection, sender)
+			if err != nil {
+				return err
+			}
+			collectionID = &collection.ID
+		}
+
+		// The same content can only be inscribed once
+		var existingInscription models.Inscription
+		result := db.Where("content_hash = ?", contentHash).First(&existingInscription)
+		if result.Error == nil {
+			return invalidOperationf("content with hash '%s' has already been inscribed", contentHash)
 		}
 
 		content, err := msg.GetContent()
 		if err != nil {
-			return err
+			return InvalidOperation(err)
 		}
 
 		// Store the content with the correct mime type on DO
 		contentPath, err := protocol.storeContent(inscriptionMetadata, rawTransaction.Hash, content)
 		if err != nil {
-			return fmt.Errorf("unable to store content '%s'", err)
+			return fmt.Errorf("unable to store content '%w'", err)
 		}
 
 		jsonBytes, err := json.Marshal(inscriptionMetadata)
@@ -137,10 +196,11 @@ func (protocol *Inscription) Process(transactionModel models.Transaction, protoc
 			Metadata:         datatypes.JSON(jsonBytes),
 			ContentPath:      contentPath,
 			ContentSizeBytes: uint64(len(content)),
+			CollectionID:     collectionID,
 			DateCreated:      transactionModel.DateCreated,
 		}
 
-		result := protocol.db.Save(&inscriptionModel)
+		result = db.Save(&inscriptionModel)
 		if result.Error != nil {
 			return result.Error
 		}
@@ -156,39 +216,30 @@ func (protocol *Inscription) Process(transactionModel models.Transaction, protoc
 			DateCreated:   transactionModel.DateCreated,
 		}
 		// If we fail to save history, that's fine
-		protocol.db.Save(&inscriptionHistory)
+		saveOptional(db, &inscriptionHistory)
 
 	case "transfer":
 		txHash := parsedURN.KeyValuePairs["h"]
 
-		// Fetch transaction from database with the given hash
-		var transaction models.Transaction
-		result := protocol.db.Where("hash = ?", txHash).First(&transaction)
-		if result.Error != nil {
-			// Invalid hash
-			return result.Error
-		}
-
-		// Fetch the inscription for this transaction ID
-		var inscription models.Inscription
-		result = protocol.db.Where("transaction_id = ?", transaction.ID).First(&inscription)
-		if result.Error != nil {
-			// Invalid transaction ID
-			return result.Error
+		inscription, err := findInscription(db, txHash)
+		if err != nil {
+			return err
 		}
 
 		// Check that the sender is the current owner
 		if inscription.CurrentOwner != sender {
-			return fmt.Errorf("invalid sender, must be current owner")
+			return invalidOperationf("invalid sender, must be current owner")
 		}
 
 		// All good, transfer
-		destinationAddress := strings.TrimSpace(parsedURN.KeyValuePairs["dst"])
-		destinationAddress = strings.ToLower(destinationAddress)
+		destinationAddress, err := NormalizeAddress(parsedURN.KeyValuePairs["dst"])
+		if err != nil {
+			return fmt.Errorf("invalid destination address '%w'", err)
+		}
 		inscription.CurrentOwner = destinationAddress
-		result = protocol.db.Save(&inscription)
+		result := db.Save(&inscription)
 		if result.Error != nil {
-			return fmt.Errorf("unable to update inscription owner '%s'", result.Error)
+			return fmt.Errorf("unable to update inscription owner '%w'", result.Error)
 		}
 
 		inscriptionHistory := models.InscriptionHistory{
@@ -202,11 +253,73 @@ func (protocol *Inscription) Process(transactionModel models.Transaction, protoc
 			DateCreated:   transactionModel.DateCreated,
 		}
 		// If we fail to save history, that's fine
-		protocol.db.Save(&inscriptionHistory)
+		saveOptional(db, &inscriptionHistory)
+	}
+	return nil
+}
+
+// findInscription fetches the inscription created by the transaction with
+// the given hash
+func findInscription(db *gorm.DB, txHash string) (models.Inscription, error) {
+	// Fetch transaction from database with the given hash
+	var transaction models.Transaction
+	result := db.Where("hash = ?", txHash).First(&transaction)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return models.Inscription{}, invalidOperationf("no transaction with hash '%s'", txHash)
+	}
+	if result.Error != nil {
+		return models.Inscription{}, result.Error
+	}
+
+	// Fetch the inscription for this transaction ID
+	var inscription models.Inscription
+	result = db.Where("transaction_id = ?", transaction.ID).First(&inscription)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return models.Inscription{}, invalidOperationf("no inscription for transaction with hash '%s'", txHash)
+	}
+	if result.Error != nil {
+		return models.Inscription{}, result.Error
+	}
+	return inscription, nil
+}
+
+// checkCollection verifies that collection was inscribed as a collection and
+// that sender is allowed to add inscriptions to it, only the current owner of
+// the collection can
+func checkCollection(collection models.Inscription, sender string) error {
+	var collectionMetadata types.InscriptionMetadata
+	err := json.Unmarshal(collection.Metadata, &collectionMetadata)
+	if err != nil || collectionMetadata.Metadata.Type != types.InscriptionTypeContentNFTCollection {
+		return invalidOperationf("inscription '%d' is not a collection", collection.ID)
+	}
+	if collection.CurrentOwner != sender {
+		return invalidOperationf("sender is not the owner of collection '%d'", collection.ID)
 	}
 	return nil
 }
 
+// isContentTypeAllowed checks contentType against the configured allowed
+// content types. Parameters such as charset are ignored
+func (protocol *Inscription) isContentTypeAllowed(contentType string) bool {
+	if len(protocol.allowedContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range protocol.allowedContentTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType {
+			return true
+		}
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
 // storeContent stores the content in the S3 bucket
 func (protocol *Inscription) storeContent(metadata *types.InscriptionMetadata, txHash string, content []byte) (string, error) {
 	ext, err := mime.ExtensionsByType(metadata.Metadata.Mime)
@@ -245,7 +358,7 @@ func (protocol *Inscription) storeContent(metadata *types.InscriptionMetadata, t
 		ContentType: aws.String(metadata.Metadata.Mime),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to upload file, %v", err)
+		return "", fmt.Errorf("failed to upload file, %w", err)
 	}
 
 	return aws.StringValue(&uploadResult.Location), nil
@@ -0,0 +1,65 @@
+package metaprotocol
+
+import (
+	"testing"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/types"
+	"gorm.io/datatypes"
+)
+
+func TestIsContentTypeAllowed(t *testing.T) {
+	protocol := &Inscription{
+		allowedContentTypes: []string{"image/*", "text/plain"},
+	}
+
+	tests := []struct {
+		contentType string
+		allowed     bool
+	}{
+		{"image/png", true},
+		{"IMAGE/WEBP", true},
+		{"text/plain; charset=utf-8", true},
+		{"text/html", false},
+		{"application/javascript", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if protocol.isContentTypeAllowed(test.contentType) != test.allowed {
+			t.Errorf("expected '%s' allowed to be %t", test.contentType, test.allowed)
+		}
+	}
+
+	// Without a configured list everything is allowed
+	protocol.allowedContentTypes = nil
+	if !protocol.isContentTypeAllowed("text/html") {
+		t.Errorf("expected all content types to be allowed without a list")
+	}
+}
+
+func TestCheckCollection(t *testing.T) {
+	collection := models.Inscription{
+		ID:           7,
+		Creator:      "cosmos1creator",
+		CurrentOwner: "cosmos1owner",
+		Metadata:     datatypes.JSON(`{"parent":{"type":"/cosmos.bank.Account","identifier":"cosmos1creator"},"metadata":{"@type":"` + types.InscriptionTypeContentNFTCollection + `","name":"Roids","description":"","mime":"image/png"}}`),
+	}
+
+	// The current owner can add to the collection
+	if err := checkCollection(collection, "cosmos1owner"); err != nil {
+		t.Errorf("expected owner to be authorized, got %s", err)
+	}
+
+	// Anyone else, including the original creator, can not
+	for _, sender := range []string{"cosmos1creator", "cosmos1other"} {
+		if err := checkCollection(collection, sender); err == nil {
+			t.Errorf("expected '%s' to be unauthorized", sender)
+		}
+	}
+
+	// Only collections can have inscriptions added to them
+	collection.Metadata = datatypes.JSON(`{"parent":{"type":"/cosmos.bank.Account","identifier":"cosmos1creator"},"metadata":{"name":"Roid","description":"","mime":"image/png"}}`)
+	if err := checkCollection(collection, "cosmos1owner"); err == nil {
+		t.Errorf("expected an inscription that is not a collection to be rejected")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +25,13 @@ type MarketplaceConfig struct {
 	LCDEndpoints    []string          `envconfig:"LCD_ENDPOINTS" required:"true"`
 	EndpointHeaders map[string]string `envconfig:"ENDPOINT_HEADERS" required:"true"`
 	IbcEnabled      bool              `envconfig:"IBC_ENABLED" default:"true"`
+	AmountPrecision string            `envconfig:"AMOUNT_PRECISION" default:"reject"`
+	// CaseSensitiveTickers is shared with the CFT-20 processor
+	CaseSensitiveTickers bool `envconfig:"CASE_SENSITIVE_TICKERS" default:"false"`
+	// AmountMaxDecimals is shared with the CFT-20 processor
+	AmountMaxDecimals uint64 `envconfig:"AMOUNT_MAX_DECIMALS" default:"18"`
+	// StrictURNKeys is shared with the CFT-20 processor
+	StrictURNKeys bool `envconfig:"STRICT_URN_KEYS" default:"false"`
 }
 
 type Marketplace struct {
@@ -35,7 +43,12 @@ type Marketplace struct {
 	minimumTradeSize     float64
 	tradeFee             float64
 	ibcEnabled           bool
+	amountPrecision      AmountPrecision
+	caseSensitiveTickers bool
+	amountMaxDecimals    uint64
+	strictURNKeys        bool
 	db                   *gorm.DB
+	balanceObserver      BalanceObserver
 
 	lcdEndpoints    []string
 	endpointHeaders map[string]string
@@ -48,6 +61,10 @@ func NewMarketplaceProcessor(chainID string, db *gorm.DB) *Marketplace {
 	if err != nil {
 		log.Fatalf("Unable to process config: %s", err)
 	}
+	amountPrecision, err := ParseAmountPrecision(config.AmountPrecision)
+	if err != nil {
+		log.Fatalf("Unable to process config: %s", err)
+	}
 
 	return &Marketplace{
 		chainID:              chainID,
@@ -58,7 +75,12 @@ func NewMarketplaceProcessor(chainID string, db *gorm.DB) *Marketplace {
 		minimumTradeSize:     config.MinimumTradeSize,
 		tradeFee:             config.TradeFee,
 		ibcEnabled:           config.IbcEnabled,
+		amountPrecision:      amountPrecision,
+		caseSensitiveTickers: config.CaseSensitiveTickers,
+		amountMaxDecimals:    config.AmountMaxDecimals,
+		strictURNKeys:        config.StrictURNKeys,
 		db:                   db,
+		balanceObserver:      noopBalanceObserver{},
 		lcdEndpoints:         config.LCDEndpoints,
 		endpointHeaders:      config.EndpointHeaders,
 	}
@@ -68,8 +90,36 @@ func (protocol *Marketplace) Name() string {
 	return "marketplace"
 }
 
+func (protocol *Marketplace) Version() string {
+	return "1.0.0"
+}
+
+// SetBalanceObserver sets the observer notified of token balance changes
+func (protocol *Marketplace) SetBalanceObserver(observer BalanceObserver) {
+	protocol.balanceObserver = observer
+}
+
+// Process applies the operation in protocolURN in a single database
+// transaction so a failure part way through leaves no partial changes behind.
+// Balance observers are only notified once the transaction has committed
 func (protocol *Marketplace) Process(currentTransaction models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error {
+	changes := &pendingBalanceChanges{}
+	err := protocol.db.Transaction(func(tx *gorm.DB) error {
+		return protocol.process(tx, changes, currentTransaction, protocolURN, rawTransaction)
+	})
+	if err != nil {
+		return err
+	}
+	changes.notify(protocol.balanceObserver)
+	return nil
+}
+
+func (protocol *Marketplace) process(db *gorm.DB, observer BalanceObserver, currentTransaction models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error {
 	sender, err := rawTransaction.GetSenderAddress()
+	if err != nil {
+		return InvalidOperation(err)
+	}
+	sender, err = NormalizeAddress(sender)
 	if err != nil {
 		return err
 	}
@@ -78,17 +128,22 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 	// {chainId}@{version};operation$h={unique hash of content}
 	// cosmoshub-4@v1beta;deploy$nam=The Name,tic=TICK,sup=21000000,dec=6,lim=1000
 	// cosmoshub-4@v1beta;deploy$nam=NewYearDay,tic=NYD,sup=28000000,dec=18,lim=50000,opn=1704059940
-	parsedURN, err := ParseProtocolString(protocolURN)
+	parsedURN, err := ParseProtocolString(protocolURN, protocol.strictURNKeys)
 	if err != nil {
 		return err
 	}
 
 	if parsedURN.ChainID != protocol.chainID {
-		return fmt.Errorf("chain ID in protocol string does not match transaction chain ID")
+		return invalidOperationf("chain ID in protocol string does not match transaction chain ID")
 	}
 
 	if parsedURN.Version != protocol.version {
-		return fmt.Errorf("version in protocol string does not match transaction version")
+		return invalidOperationf("version in protocol string does not match transaction version")
+	}
+
+	err = parsedURN.CheckExpiry(currentTransaction.Height)
+	if err != nil {
+		return err
 	}
 
 	currentHeight := currentTransaction.Height
@@ -97,14 +152,15 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 	switch parsedURN.Operation {
 	case "list.cft20":
 
-		ticker := strings.TrimSpace(parsedURN.KeyValuePairs["tic"])
-		ticker = strings.ToUpper(ticker)
+		ticker := normalizeTicker(parsedURN.KeyValuePairs["tic"], protocol.caseSensitiveTickers)
 
 		// Check if the ticker exists
-		var tokenModel models.Token
-		result := protocol.db.Where("chain_id = ? AND ticker = ?", parsedURN.ChainID, ticker).First(&tokenModel)
-		if result.Error != nil {
-			return fmt.Errorf("token with ticker '%s' doesn't exist", ticker)
+		tokenModel, err := findToken(db, parsedURN.ChainID, ticker, parsedURN.KeyValuePairs["tid"])
+		if err != nil {
+			return err
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", ticker)
 		}
 
 		// We will actually be sending the tokens to the marketplace address
@@ -113,31 +169,31 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Check required fields
 		amountString := strings.TrimSpace(parsedURN.KeyValuePairs["amt"])
 		// Convert amount to have the correct number of decimals
-		amount, err := strconv.ParseFloat(amountString, 64)
+		precision, err := tokenAmountPrecision(protocol.amountPrecision, tokenModel)
 		if err != nil {
-			return fmt.Errorf("unable to parse amount '%s'", err)
+			return err
 		}
-		if amount <= 0 {
-			return fmt.Errorf("amount must be greater than 0")
+		amount, err := ValidateAndScaleAmountWithPrecision(amountString, uint(tokenModel.Decimals), big.NewInt(1), tokenMaxAmount(tokenModel), precision, protocol.amountMaxDecimals)
+		if err != nil {
+			return fmt.Errorf("unable to parse amount '%w'", err)
 		}
 
 		pptString := strings.TrimSpace(parsedURN.KeyValuePairs["ppt"])
 		// Convert amount to have the correct number of decimals
 		ppt, err := strconv.ParseFloat(pptString, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse ppt '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse ppt '%w'", err))
 		}
 		if ppt <= 0 {
-			return fmt.Errorf("price per token must be greater than 0")
+			return invalidOperationf("price per token must be greater than 0")
 		}
-		totalBase := float64(amount) * ppt
+		totalBase := amount.Float64() / math.Pow10(int(tokenModel.Decimals)) * ppt
 		if totalBase < protocol.minimumTradeSize {
-			return fmt.Errorf("total trade size must be greater than %.6f", protocol.minimumTradeSize)
+			return invalidOperationf("total trade size must be greater than %.6f", protocol.minimumTradeSize)
 		}
 
 		// 6 is the amount of ATOM decimals
 		ppt = ppt * math.Pow10(6)
-		amount = amount * math.Pow10(int(tokenModel.Decimals))
 		totalBase = totalBase * math.Pow10(6)
 
 		// Get the minimum deposit
@@ -145,13 +201,13 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Convert amount to have the correct number of decimals
 		minDeposit, err := strconv.ParseFloat(minDepositString, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse ppt '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse ppt '%w'", err))
 		}
 		if minDeposit <= 0 {
-			return fmt.Errorf("minimum deposit must be greater than 0")
+			return invalidOperationf("minimum deposit must be greater than 0")
 		}
 		if minDeposit < protocol.minimumDeposit {
-			return fmt.Errorf("minimum deposit percentage too small")
+			return invalidOperationf("minimum deposit percentage too small")
 		}
 
 		// Calculate the ATOM amount of the minimum deposit by checking against
@@ -165,38 +221,52 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		timeoutString := strings.TrimSpace(parsedURN.KeyValuePairs["to"])
 		timeout, err := strconv.ParseUint(timeoutString, 10, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse to '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse to '%w'", err))
 		}
 		if timeout < protocol.minimumTimeoutBlocks {
-			return fmt.Errorf("timeout must be greater than the minimum of %d", protocol.minimumTimeoutBlocks)
+			return invalidOperationf("timeout must be greater than the minimum of %d", protocol.minimumTimeoutBlocks)
+		}
+
+		// Convert the base amounts before anything is changed so that values
+		// that don't fit in a uint64 are rejected instead of truncated
+		listingTotal, err := toUint64(math.Round(totalBase))
+		if err != nil {
+			return fmt.Errorf("invalid listing total '%w'", err)
+		}
+		depositTotal, err := toUint64(math.Round(minDepositBase))
+		if err != nil {
+			return fmt.Errorf("invalid listing deposit '%w'", err)
+		}
+		pptBase, err := toUint64(math.Round(ppt))
+		if err != nil {
+			return fmt.Errorf("invalid price per token '%w'", err)
 		}
 
 		// Verify that the sender has sent enough tokens to cover the listing fee
 		amountSent, err := GetBaseTokensSent(rawTransaction, IbcTransfer, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
-		if amountSent < uint64(math.Floor(minDepositBase)) {
-			return fmt.Errorf("sender did not send enough tokens to cover the listing fee")
+		if float64(amountSent) < math.Floor(minDepositBase) {
+			return invalidOperationf("sender did not send enough tokens to cover the listing fee")
 		}
 
 		// Check that the user has enough tokens to sell
 		var holderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
+		result := db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, tokenModel.ID, sender).First(&holderModel)
 		if result.Error != nil {
-			return fmt.Errorf("sender does not have any tokens to sell")
+			return invalidOperationf("sender does not have any tokens to sell")
 		}
 
-		if holderModel.Amount < uint64(amount) {
-			return fmt.Errorf("sender does not have enough tokens to sell")
+		if holderModel.Amount.LT(amount) {
+			return invalidOperationf("sender does not have enough tokens to sell")
 		}
 
 		// At this point we know that the sender has enough tokens to sell
 		// so decrease the senders balance
-		holderModel.Amount = holderModel.Amount - uint64(amount)
-		result = protocol.db.Save(&holderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update seller's balance '%s'", err)
+		err = updateHolderBalance(db, observer, &holderModel, holderModel.Amount.Sub(amount))
+		if err != nil {
+			return fmt.Errorf("unable to update seller's balance '%w'", err)
 		}
 
 		// Create a listing position
@@ -204,8 +274,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			ChainID:          parsedURN.ChainID,
 			TransactionID:    currentTransaction.ID,
 			SellerAddress:    sender,
-			Total:            uint64(math.Round(totalBase)),
-			DepositTotal:     uint64(math.Round(minDepositBase)),
+			Total:            listingTotal,
+			DepositTotal:     depositTotal,
 			DepositorAddress: "",
 			DepositTimeout:   timeout,
 			IsDeposited:      false,
@@ -214,21 +284,21 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			DateUpdated:      currentTransaction.DateCreated,
 			DateCreated:      currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listing)
+		result = db.Save(&listing)
 		if result.Error != nil {
-			return fmt.Errorf("unable to create listing '%s'", result.Error)
+			return fmt.Errorf("unable to create listing '%w'", result.Error)
 		}
 
 		listingDetail := models.MarketplaceCFT20Detail{
 			ListingID:   listing.ID,
 			TokenID:     tokenModel.ID,
-			Amount:      uint64(math.Round(amount)),
-			PPT:         uint64(math.Round(ppt)),
+			Amount:      amount,
+			PPT:         pptBase,
 			DateCreated: currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingDetail)
+		result = db.Save(&listingDetail)
 		if result.Error != nil {
-			return fmt.Errorf("unable to create token listing '%s'", result.Error)
+			return fmt.Errorf("unable to create token listing '%w'", result.Error)
 		}
 
 		// Record the transfer
@@ -240,13 +310,13 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Sender:        sender,
 			Receiver:      destinationAddress,
 			Action:        "list",
-			Amount:        uint64(math.Round(amount)),
+			Amount:        amount,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
-		if result.Error != nil {
+		err = saveOptional(db, &historyModel)
+		if err != nil {
 			// If we can't store the history, that fine, we shouldn't fail
-			_ = result
+			_ = err
 		}
 
 		// Record the listing history
@@ -257,8 +327,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        "list",
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &listingHistory)
+		if err != nil {
 			// If we can't store the history, that is fine, we shouldn't fail
 			return nil
 		}
@@ -270,20 +340,20 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Check if the inscription exists
 		// Inscriptions are stored by their transaction hash
 		var transactionModel models.Transaction
-		result := protocol.db.Debug().Where("hash = ?", hash).First(&transactionModel)
+		result := db.Debug().Where("hash = ?", hash).First(&transactionModel)
 		if result.Error != nil {
-			return fmt.Errorf("inscription with hash '%s' doesn't exist", hash)
+			return invalidOperationf("inscription with hash '%s' doesn't exist", hash)
 		}
 
 		var inscriptionModel models.Inscription
-		result = protocol.db.Where("transaction_id = ?", transactionModel.ID).First(&inscriptionModel)
+		result = db.Where("transaction_id = ?", transactionModel.ID).First(&inscriptionModel)
 		if result.Error != nil {
-			return fmt.Errorf("inscription with hash '%s' couldn't be found", hash)
+			return invalidOperationf("inscription with hash '%s' couldn't be found", hash)
 		}
 
 		// Verify the address creating the listing is the owner of the inscription
 		if inscriptionModel.CurrentOwner != sender {
-			return fmt.Errorf("sender is not the owner of the inscription")
+			return invalidOperationf("sender is not the owner of the inscription")
 		}
 
 		// We will actually be sending the inscription to the marketplace address
@@ -294,10 +364,10 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Convert amount to have the correct number of decimals
 		amount, err := strconv.ParseFloat(amountString, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse amount '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse amount '%w'", err))
 		}
 		if amount <= 0 {
-			return fmt.Errorf("amount must be greater than 0")
+			return invalidOperationf("amount must be greater than 0")
 		}
 
 		// 6 is the amount of ATOM decimals
@@ -308,14 +378,14 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Convert amount to have the correct number of decimals
 		minDeposit, err := strconv.ParseFloat(minDepositString, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse mindep '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse mindep '%w'", err))
 		}
 		if minDeposit <= 0 {
-			return fmt.Errorf("minimum deposit must be greater than 0")
+			return invalidOperationf("minimum deposit must be greater than 0")
 		}
 		// TODO: Move 0.00001 (0.001%) to config as the minimum deposit percent
 		if minDeposit < 0.00001 {
-			return fmt.Errorf("minimum deposit percentage too small")
+			return invalidOperationf("minimum deposit percentage too small")
 		}
 
 		// Calculate the ATOM amount of the minimum deposit by checking against
@@ -329,28 +399,39 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		timeoutString := strings.TrimSpace(parsedURN.KeyValuePairs["to"])
 		timeout, err := strconv.ParseUint(timeoutString, 10, 64)
 		if err != nil {
-			return fmt.Errorf("unable to parse to '%s'", err)
+			return InvalidOperation(fmt.Errorf("unable to parse to '%w'", err))
 		}
 		if timeout < protocol.minimumTimeoutBlocks {
-			return fmt.Errorf("timeout must be greater than the minimum of %d", protocol.minimumTimeoutBlocks)
+			return invalidOperationf("timeout must be greater than the minimum of %d", protocol.minimumTimeoutBlocks)
+		}
+
+		// Convert the base amounts before anything is changed so that values
+		// that don't fit in a uint64 are rejected instead of truncated
+		listingTotal, err := toUint64(math.Round(totalBase))
+		if err != nil {
+			return fmt.Errorf("invalid listing total '%w'", err)
+		}
+		depositTotal, err := toUint64(math.Round(minDepositBase))
+		if err != nil {
+			return fmt.Errorf("invalid listing deposit '%w'", err)
 		}
 
 		// Check that the correct amount was sent with the buy
 		amountSent, err := GetBaseTokensSent(rawTransaction, IbcTransfer, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
 
-		if amountSent < uint64(math.Floor(minDepositBase)) {
-			return fmt.Errorf("sender did not send enough tokens to cover the listing fee")
+		if float64(amountSent) < math.Floor(minDepositBase) {
+			return invalidOperationf("sender did not send enough tokens to cover the listing fee")
 		}
 
 		// At this point we know that the sender has the inscription and everything
 		// checks out, transfer the inscription to the market
 		inscriptionModel.CurrentOwner = destinationAddress
-		result = protocol.db.Save(&inscriptionModel)
+		result = db.Save(&inscriptionModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to transfer to marketplace '%s'", err)
+			return fmt.Errorf("unable to transfer to marketplace '%w'", result.Error)
 		}
 
 		// Create a listing position
@@ -358,8 +439,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			ChainID:          parsedURN.ChainID,
 			TransactionID:    currentTransaction.ID,
 			SellerAddress:    sender,
-			Total:            uint64(math.Round(totalBase)),
-			DepositTotal:     uint64(math.Round(minDepositBase)),
+			Total:            listingTotal,
+			DepositTotal:     depositTotal,
 			DepositorAddress: "",
 			DepositTimeout:   timeout,
 			IsDeposited:      false,
@@ -368,9 +449,9 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			DateUpdated:      currentTransaction.DateCreated,
 			DateCreated:      currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listing)
+		result = db.Save(&listing)
 		if result.Error != nil {
-			return fmt.Errorf("unable to create listing '%s'", result.Error)
+			return fmt.Errorf("unable to create listing '%w'", result.Error)
 		}
 
 		listingDetail := models.MarketplaceInscriptionDetail{
@@ -378,9 +459,9 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			InscriptionID: inscriptionModel.ID,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingDetail)
+		result = db.Save(&listingDetail)
 		if result.Error != nil {
-			return fmt.Errorf("unable to create token listing '%s'", result.Error)
+			return fmt.Errorf("unable to create token listing '%w'", result.Error)
 		}
 
 		// Record the transfer
@@ -394,10 +475,10 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        "list",
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
-		if result.Error != nil {
+		err = saveOptional(db, &historyModel)
+		if err != nil {
 			// If we can't store the history, that fine, we shouldn't fail
-			_ = result
+			_ = err
 		}
 
 		// Record the listing history
@@ -408,8 +489,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        "list",
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &listingHistory)
+		if err != nil {
 			// If we can't store the history, that is fine, we shouldn't fail
 			return nil
 		}
@@ -421,21 +502,21 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Deposits are based on the listing transaction hash, find the transaction
 		// and matching listing
 		var transactionModel models.Transaction
-		result := protocol.db.Where("hash = ?", hash).First(&transactionModel)
+		result := db.Where("hash = ?", hash).First(&transactionModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing transaction with hash '%s'", hash)
+			return invalidOperationf("no listing transaction with hash '%s'", hash)
 		}
 
 		// Fetch listing based on hash
 		var listingModel models.MarketplaceListing
-		result = protocol.db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
+		result = db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing with hash '%s'", hash)
+			return invalidOperationf("no listing with hash '%s'", hash)
 		}
 
 		if listingModel.IsDeposited {
 			if listingModel.DepositorTimeoutBlock > currentHeight {
-				return fmt.Errorf("listing already has a deposit")
+				return invalidOperationf("listing already has a deposit")
 			}
 			// Has deposit, but expired, so we continue
 			action = "deposit after expiry"
@@ -446,18 +527,18 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 
 		if listingModel.Total >= listingModel.DepositTotal {
 			if balance < listingModel.Total-listingModel.DepositTotal {
-				return fmt.Errorf("sender does not have enough ATOM to complete the purchase after deposit")
+				return invalidOperationf("sender does not have enough ATOM to complete the purchase after deposit")
 			}
 		}
 
 		// Check that the correct amount was sent with the deposit
 		amountSent, err := GetBaseTokensSent(rawTransaction, Send, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
 
 		if amountSent < listingModel.DepositTotal {
-			return fmt.Errorf("sender did not send enough tokens to cover the deposit")
+			return invalidOperationf("sender did not send enough tokens to cover the deposit")
 		}
 
 		// Everything checks out, add this as the depositor
@@ -466,8 +547,11 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		listingModel.DateUpdated = currentTransaction.DateCreated
 		// Timed-out block is the first block after the expiry period when the
 		// listing is deemed expired
+		if listingModel.DepositTimeout >= math.MaxUint64-currentHeight {
+			return invalidOperationf("deposit timeout of %d blocks overflows the block height", listingModel.DepositTimeout)
+		}
 		listingModel.DepositorTimeoutBlock = currentHeight + listingModel.DepositTimeout + 1
-		result = protocol.db.Save(&listingModel)
+		result = db.Save(&listingModel)
 		if result.Error != nil {
 			return result.Error
 		}
@@ -480,8 +564,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        action,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &listingHistory)
+		if err != nil {
 			// If we can't store the history, that is fine, we shouldn't fail
 			return nil
 		}
@@ -493,35 +577,47 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Deposits are based on the listing transaction hash, find the transaction
 		// and matching listing
 		var transactionModel models.Transaction
-		result := protocol.db.Where("hash = ?", hash).First(&transactionModel)
+		result := db.Where("hash = ?", hash).First(&transactionModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing transaction with hash '%s'", hash)
+			return invalidOperationf("no listing transaction with hash '%s'", hash)
 		}
 
 		// Fetch listing based on hash
 		var listingModel models.MarketplaceListing
-		result = protocol.db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
+		result = db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing with hash '%s'", hash)
+			return invalidOperationf("no listing with hash '%s'", hash)
 		}
 
 		if listingModel.SellerAddress != sender {
-			return fmt.Errorf("sender is not the seller of the listing")
+			return invalidOperationf("sender is not the seller of the listing")
 		}
 
 		if listingModel.IsDeposited {
 			if listingModel.DepositorTimeoutBlock > currentHeight {
-				return fmt.Errorf("listing already has a deposit, cannot be cancelled until expiry")
+				return invalidOperationf("listing already has a deposit, cannot be cancelled until expiry")
 			}
 			// Has deposit, but expired, so we continue
 			action = "delist after expiry"
 		}
 
 		if listingModel.IsFilled {
-			return fmt.Errorf("listing has already been filled, cannot be cancelled")
+			return invalidOperationf("listing has already been filled, cannot be cancelled")
 		}
 		if listingModel.IsCancelled {
-			return fmt.Errorf("listing has already been cancelled")
+			return invalidOperationf("listing has already been cancelled")
+		}
+
+		// Cancelling a CFT-20 listing returns the tokens to the seller, which
+		// isn't allowed while the token is frozen
+		var frozenCheckDetailModel models.MarketplaceCFT20Detail
+		result = db.Where("listing_id = ?", listingModel.ID).First(&frozenCheckDetailModel)
+		if result.Error == nil {
+			var tokenModel models.Token
+			result = db.Where("id = ?", frozenCheckDetailModel.TokenID).First(&tokenModel)
+			if result.Error == nil && tokenModel.IsFrozen {
+				return invalidOperationf("token with ticker '%s' is frozen", tokenModel.Ticker)
+			}
 		}
 
 		listingModel.IsDeposited = false
@@ -529,9 +625,9 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		listingModel.DepositorTimeoutBlock = 0
 		listingModel.IsCancelled = true
 		listingModel.DateUpdated = currentTransaction.DateCreated
-		result = protocol.db.Save(&listingModel)
+		result = db.Save(&listingModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to cancel listing: %s", result.Error)
+			return fmt.Errorf("unable to cancel listing: %w", result.Error)
 		}
 
 		// Update listing history
@@ -542,27 +638,26 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        action,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &listingHistory)
+		if err != nil {
 			// If we can't store the history, that is fine, we shouldn't fail
 			return nil
 		}
 
 		// Check if this is a CFT-20 token listing
 		var listingDetailModel models.MarketplaceCFT20Detail
-		result = protocol.db.Where("listing_id = ?", listingModel.ID).First(&listingDetailModel)
+		result = db.Where("listing_id = ?", listingModel.ID).First(&listingDetailModel)
 		if result.Error == nil {
 			// This is CFT-20 listing, continue by returning funds
 			var holderModel models.TokenHolder
-			result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, listingDetailModel.TokenID, sender).First(&holderModel)
+			result = db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, listingDetailModel.TokenID, sender).First(&holderModel)
 			if result.Error != nil {
-				return fmt.Errorf("sender never had tokens to sell")
+				return invalidOperationf("sender never had tokens to sell")
 			}
 
-			holderModel.Amount = holderModel.Amount + listingDetailModel.Amount
-			result = protocol.db.Save(&holderModel)
-			if result.Error != nil {
-				return fmt.Errorf("unable to update seller's balance '%s'", err)
+			err = updateHolderBalance(db, observer, &holderModel, holderModel.Amount.Add(listingDetailModel.Amount))
+			if err != nil {
+				return fmt.Errorf("unable to update seller's balance '%w'", err)
 			}
 
 			// Log history
@@ -576,11 +671,11 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 				Sender:        protocol.virtualAddress,
 				Receiver:      sender,
 				Action:        action,
-				Amount:        uint64(listingDetailModel.Amount),
+				Amount:        listingDetailModel.Amount,
 				DateCreated:   currentTransaction.DateCreated,
 			}
-			result = protocol.db.Save(&historyModel)
-			if result.Error != nil {
+			err = saveOptional(db, &historyModel)
+			if err != nil {
 				// If we can't store the history, that fine, we shouldn't fail
 				return nil
 			}
@@ -589,19 +684,19 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		}
 
 		var inscriptionListingDetailModel models.MarketplaceInscriptionDetail
-		result = protocol.db.Where("listing_id = ?", listingModel.ID).First(&inscriptionListingDetailModel)
+		result = db.Where("listing_id = ?", listingModel.ID).First(&inscriptionListingDetailModel)
 		if result.Error == nil {
 			// This is an inscription listing, continue by returning the inscription
 			var inscriptionModel models.Inscription
-			result = protocol.db.Where("chain_id = ? AND id = ?", parsedURN.ChainID, inscriptionListingDetailModel.InscriptionID).First(&inscriptionModel)
+			result = db.Where("chain_id = ? AND id = ?", parsedURN.ChainID, inscriptionListingDetailModel.InscriptionID).First(&inscriptionModel)
 			if result.Error != nil {
-				return fmt.Errorf("sender never had this inscription to sell")
+				return invalidOperationf("sender never had this inscription to sell")
 			}
 
 			inscriptionModel.CurrentOwner = sender
-			result = protocol.db.Save(&inscriptionModel)
+			result = db.Save(&inscriptionModel)
 			if result.Error != nil {
-				return fmt.Errorf("unable to update inscription's owner '%s'", err)
+				return fmt.Errorf("unable to update inscription's owner '%w'", result.Error)
 			}
 
 			// Log history
@@ -616,10 +711,10 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 				Action:        "delist",
 				DateCreated:   currentTransaction.DateCreated,
 			}
-			result = protocol.db.Save(&historyModel)
-			if result.Error != nil {
+			err = saveOptional(db, &historyModel)
+			if err != nil {
 				// If we can't store the history, that fine, we shouldn't fail
-				_ = result
+				_ = err
 			}
 
 			// Record the listing history
@@ -630,8 +725,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 				Action:        "delist",
 				DateCreated:   currentTransaction.DateCreated,
 			}
-			result = protocol.db.Save(&listingHistory)
-			if result.Error != nil {
+			err = saveOptional(db, &listingHistory)
+			if err != nil {
 				// If we can't store the history, that is fine, we shouldn't fail
 				return nil
 			}
@@ -645,31 +740,44 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Buys are based on the listing transaction hash, find the transaction
 		// and matching listing
 		var transactionModel models.Transaction
-		result := protocol.db.Where("hash = ?", hash).First(&transactionModel)
+		result := db.Where("hash = ?", hash).First(&transactionModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing transaction with hash '%s'", hash)
+			return invalidOperationf("no listing transaction with hash '%s'", hash)
 		}
 
 		// Fetch listing based on hash
 		var listingModel models.MarketplaceListing
-		result = protocol.db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
+		result = db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing with hash '%s'", hash)
+			return invalidOperationf("no listing with hash '%s'", hash)
 		}
 
 		// Fetch CFT-20 listing detail
 		var listingDetailModel models.MarketplaceCFT20Detail
-		result = protocol.db.Where("listing_id = ?", listingModel.ID).First(&listingDetailModel)
+		result = db.Where("listing_id = ?", listingModel.ID).First(&listingDetailModel)
 		if result.Error != nil {
-			return fmt.Errorf("no CFT-20 listing with hash '%s'", hash)
+			return invalidOperationf("no CFT-20 listing with hash '%s'", hash)
+		}
+
+		// Fetch the token being bought
+		var tokenModel models.Token
+		result = db.Unscoped().Where("id = ?", listingDetailModel.TokenID).First(&tokenModel)
+		if result.Error != nil {
+			return invalidOperationf("token for listing with hash '%s' doesn't exist", hash)
+		}
+		if tokenModel.IsRetired() {
+			return invalidOperationf("token with ticker '%s' has been retired", tokenModel.Ticker)
+		}
+		if tokenModel.IsFrozen {
+			return invalidOperationf("token with ticker '%s' is frozen", tokenModel.Ticker)
 		}
 
 		if listingModel.IsDeposited {
 			if listingModel.DepositorAddress != sender {
-				return fmt.Errorf("sender is not the depositor of the listing, buyer must deposit first")
+				return invalidOperationf("sender is not the depositor of the listing, buyer must deposit first")
 			}
 		} else {
-			return fmt.Errorf("listing has not been deposited, buyer must deposit first")
+			return invalidOperationf("listing has not been deposited, buyer must deposit first")
 		}
 
 		// Check the amount still owed after deposit
@@ -678,11 +786,11 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Check that the correct amount was sent with the buy
 		amountSent, err := GetBaseTokensSent(rawTransaction, Send, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
 
 		if amountSent < amountOwed {
-			return fmt.Errorf("sender did not send enough tokens to complete the buy")
+			return invalidOperationf("sender did not send enough tokens to complete the buy")
 		}
 
 		// Verify that the sender sent enough to cover the feee
@@ -697,23 +805,23 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Verify that the sender has sent enough tokens to cover the fee
 		amountSent, err = GetBaseTokensSent(rawTransaction, IbcTransfer, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
-		if amountSent < uint64(math.Floor(requiredFeeAbsolute)) {
-			return fmt.Errorf("sender did not send enough tokens to cover the purchase fee")
+		if float64(amountSent) < math.Floor(requiredFeeAbsolute) {
+			return invalidOperationf("sender did not send enough tokens to cover the purchase fee")
 		}
 
 		// Everything checks out, complete the buy and transfer the tokens to the buyer
 		listingModel.IsFilled = true
 		listingModel.DateUpdated = currentTransaction.DateCreated
-		result = protocol.db.Save(&listingModel)
+		result = db.Save(&listingModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 		// Check if the receiver has any tokens already, if not, add
 		var holderModel models.TokenHolder
-		result = protocol.db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, listingDetailModel.TokenID, sender).First(&holderModel)
+		result = db.Where("chain_id = ? AND token_id = ? AND address = ?", parsedURN.ChainID, listingDetailModel.TokenID, sender).First(&holderModel)
 		if result.Error != nil {
 			// Just means this buyer doesn't have the tokens in their wallet yet
 			_ = result
@@ -722,11 +830,10 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		holderModel.ChainID = parsedURN.ChainID
 		holderModel.TokenID = listingDetailModel.TokenID
 		holderModel.Address = sender
-		holderModel.Amount = holderModel.Amount + listingDetailModel.Amount
 		holderModel.DateUpdated = currentTransaction.DateCreated
-		result = protocol.db.Save(&holderModel)
-		if result.Error != nil {
-			return fmt.Errorf("unable to update buyer's balance '%s'", err)
+		err = updateHolderBalance(db, observer, &holderModel, holderModel.Amount.Add(listingDetailModel.Amount))
+		if err != nil {
+			return fmt.Errorf("unable to update buyer's balance '%w'", err)
 		}
 
 		// Record the listing history
@@ -737,8 +844,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        action,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &listingHistory)
+		if err != nil {
 			// If we can't store the history, that is fine, we shouldn't fail
 			return nil
 		}
@@ -755,10 +862,10 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Amount:        listingDetailModel.Amount,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
-		if result.Error != nil {
+		err = saveOptional(db, &historyModel)
+		if err != nil {
 			// If we can't store the history, that fine, we shouldn't fail
-			_ = result
+			_ = err
 		}
 
 		// Record the sale of the tokens for the seller
@@ -773,15 +880,15 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Amount:        listingDetailModel.Amount,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
-		if result.Error != nil {
+		err = saveOptional(db, &historyModel)
+		if err != nil {
 			// If we can't store the history, that fine, we shouldn't fail
-			_ = result
+			_ = err
 		}
 
 		// Get current USD price of the base
 		var statusModel models.Status
-		result = protocol.db.Where("chain_id = ?", parsedURN.ChainID).First(&statusModel)
+		result = db.Where("chain_id = ?", parsedURN.ChainID).First(&statusModel)
 		if result.Error != nil {
 			// If this fails we just don't update the history
 			return nil
@@ -801,58 +908,49 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			TotalUSD:      totalWithDecimals * statusModel.BaseTokenUSD,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&tradeHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &tradeHistory)
+		if err != nil {
 			// Continue, this is not critical
-			_ = result
-		}
-
-		// Check if the ticker exists
-		var tokenModel models.Token
-		result = protocol.db.Where("id = ?", listingDetailModel.TokenID).First(&tokenModel)
-		if result.Error != nil {
-			// This can fail silently as to not alarm the user
-			return nil
-		}
-
-		var avgPrice uint64
-		dberr := protocol.db.Raw(`
-		SELECT round(AVG(rate)) AS average_price 
-		FROM (
-			SELECT rate 
-			FROM token_trade_history tth 
-			WHERE token_id = ? 
-			AND amount_quote > 1000000 
-			ORDER BY id 
-			DESC LIMIT 30
-		) AS last_records`, tokenModel.ID).Scan(&avgPrice)
-		if dberr.Error != nil {
-			// No need to alert the buyer
-			return nil
-		}
+			_ = err
+		}
+
+		err = runOptional(db, func(tx *gorm.DB) error {
+			var avgPrice uint64
+			err := tx.Raw(`
+			SELECT round(AVG(rate)) AS average_price 
+			FROM (
+				SELECT rate 
+				FROM token_trade_history tth 
+				WHERE token_id = ? 
+				AND amount_quote > 1000000 
+				ORDER BY id 
+				DESC LIMIT 30
+			) AS last_records`, tokenModel.ID).Scan(&avgPrice).Error
+			if err != nil {
+				return err
+			}
 
-		// Recalculate volume from filled trades for this token in past 24 hours
-		// SELECT sum(total_usd) from token_trade_history where date_Created >= now - 24 hours and token_id = this token id
-		var sum uint64
-		err = protocol.db.Model(&models.TokenTradeHistory{}).
-			Select("SUM(amount_quote)").
-			Where("date_created >= ?", time.Now().Add(-24*time.Hour)).
-			Where("token_id = ?", tokenModel.ID).
-			Find(&sum).Error
+			// Recalculate volume from filled trades for this token in past 24 hours
+			// SELECT sum(total_usd) from token_trade_history where date_Created >= now - 24 hours and token_id = this token id
+			var sum uint64
+			err = tx.Model(&models.TokenTradeHistory{}).
+				Select("SUM(amount_quote)").
+				Where("date_created >= ?", time.Now().Add(-24*time.Hour)).
+				Where("token_id = ?", tokenModel.ID).
+				Find(&sum).Error
+			if err != nil {
+				return err
+			}
 
+			tokenModel.LastPriceBase = avgPrice
+			tokenModel.Volume24Base = sum
+			return tx.Save(&tokenModel).Error
+		})
 		if err != nil {
 			// This can fail silently as to not alarm the user
 			return nil
 		}
 
-		tokenModel.LastPriceBase = avgPrice
-		tokenModel.Volume24Base = sum
-		result = protocol.db.Save(&tokenModel)
-		if result.Error != nil {
-			// This can fail silently as to not alarm the user
-			return nil
-		}
-
 	case "buy.inscription":
 		action := "buy"
 		hash := strings.TrimSpace(parsedURN.KeyValuePairs["h"])
@@ -860,31 +958,31 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Buys are based on the listing transaction hash, find the transaction
 		// and matching listing
 		var transactionModel models.Transaction
-		result := protocol.db.Where("hash = ?", hash).First(&transactionModel)
+		result := db.Where("hash = ?", hash).First(&transactionModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing transaction with hash '%s'", hash)
+			return invalidOperationf("no listing transaction with hash '%s'", hash)
 		}
 
 		// Fetch listing based on hash
 		var listingModel models.MarketplaceListing
-		result = protocol.db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
+		result = db.Where("chain_id = ? AND transaction_id = ?", parsedURN.ChainID, transactionModel.ID).First(&listingModel)
 		if result.Error != nil {
-			return fmt.Errorf("no listing with hash '%s'", hash)
+			return invalidOperationf("no listing with hash '%s'", hash)
 		}
 
 		// Fetch inscription listing detail
 		var listingDetailModel models.MarketplaceInscriptionDetail
-		result = protocol.db.Where("listing_id = ?", listingModel.ID).First(&listingDetailModel)
+		result = db.Where("listing_id = ?", listingModel.ID).First(&listingDetailModel)
 		if result.Error != nil {
-			return fmt.Errorf("no inscription listing with hash '%s'", hash)
+			return invalidOperationf("no inscription listing with hash '%s'", hash)
 		}
 
 		if listingModel.IsDeposited {
 			if listingModel.DepositorAddress != sender {
-				return fmt.Errorf("sender is not the depositor of the listing, buyer must deposit first")
+				return invalidOperationf("sender is not the depositor of the listing, buyer must deposit first")
 			}
 		} else {
-			return fmt.Errorf("listing has not been deposited, buyer must deposit first")
+			return invalidOperationf("listing has not been deposited, buyer must deposit first")
 		}
 
 		// Check the amount still owed after deposit
@@ -893,11 +991,11 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Check that the correct amount was sent with the buy
 		amountSent, err := GetBaseTokensSent(rawTransaction, Send, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
 
 		if amountSent < amountOwed {
-			return fmt.Errorf("sender did not send enough tokens to complete the buy")
+			return invalidOperationf("sender did not send enough tokens to complete the buy")
 		}
 
 		// Verify that the sender sent enough to cover the feee
@@ -912,32 +1010,32 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 		// Check that the correct amount was sent with the buy
 		amountSent, err = GetBaseTokensSent(rawTransaction, IbcTransfer, protocol.ibcEnabled)
 		if err != nil {
-			return fmt.Errorf("invalid tokens sent '%s'", err)
+			return fmt.Errorf("invalid tokens sent '%w'", err)
 		}
-		if amountSent < uint64(math.Floor(requiredFeeAbsolute)) {
-			return fmt.Errorf("sender did not send enough tokens to cover the purchase fee")
+		if float64(amountSent) < math.Floor(requiredFeeAbsolute) {
+			return invalidOperationf("sender did not send enough tokens to cover the purchase fee")
 		}
 
 		// Everything checks out, complete the buy and transfer the tokens to the buyer
 		listingModel.IsFilled = true
 		listingModel.DateUpdated = currentTransaction.DateCreated
-		result = protocol.db.Save(&listingModel)
+		result = db.Save(&listingModel)
 		if result.Error != nil {
 			return result.Error
 		}
 
 		// Set the sender as the new owner of the inscription
 		var inscriptionModel models.Inscription
-		result = protocol.db.Where("chain_id = ? AND id = ?", parsedURN.ChainID, listingDetailModel.InscriptionID).First(&inscriptionModel)
+		result = db.Where("chain_id = ? AND id = ?", parsedURN.ChainID, listingDetailModel.InscriptionID).First(&inscriptionModel)
 		if result.Error != nil {
 			// Somehow this inscription doesn't exist?
 			return result.Error
 		}
 
 		inscriptionModel.CurrentOwner = sender
-		result = protocol.db.Save(&inscriptionModel)
+		result = db.Save(&inscriptionModel)
 		if result.Error != nil {
-			return fmt.Errorf("unable to update owner '%s'", err)
+			return fmt.Errorf("unable to update owner '%w'", result.Error)
 		}
 
 		// Record the listing history
@@ -948,8 +1046,8 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        action,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&listingHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &listingHistory)
+		if err != nil {
 			// If we can't store the history, that is fine, we shouldn't fail
 			return nil
 		}
@@ -965,17 +1063,17 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			Action:        action,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&historyModel)
-		if result.Error != nil {
+		err = saveOptional(db, &historyModel)
+		if err != nil {
 			// If we can't store the history, that fine, we shouldn't fail
-			_ = result
+			_ = err
 		}
 
 		// CAPTURE TRADE HISTORY FOR VOLUME
 
 		// Get current USD price of the base
 		var statusModel models.Status
-		result = protocol.db.Where("chain_id = ?", parsedURN.ChainID).First(&statusModel)
+		result = db.Where("chain_id = ?", parsedURN.ChainID).First(&statusModel)
 		if result.Error != nil {
 			// If this fails we just don't update the history
 			return nil
@@ -993,10 +1091,10 @@ func (protocol *Marketplace) Process(currentTransaction models.Transaction, prot
 			TotalUSD:      totalWithDecimals * statusModel.BaseTokenUSD,
 			DateCreated:   currentTransaction.DateCreated,
 		}
-		result = protocol.db.Save(&tradeHistory)
-		if result.Error != nil {
+		err = saveOptional(db, &tradeHistory)
+		if err != nil {
 			// Continue, this is not critical
-			_ = result
+			_ = err
 		}
 	}
 
@@ -0,0 +1,122 @@
+package metaprotocol
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/internal/dbtest"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"gorm.io/gorm"
+)
+
+func TestProcessBuyCFT20KeepsTradeWhenStatisticsFail(t *testing.T) {
+	seller := "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95"
+	buyer := "cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"
+
+	pool := &dbtest.ConnPool{}
+	db := dbtest.OpenWithPool(t, pool)
+	dbtest.StubQueries(t, db,
+		&models.Transaction{ID: 2, Hash: "LISTING"},
+		&models.MarketplaceListing{ID: 3, ChainID: "cosmoshub-4", TransactionID: 2, SellerAddress: seller, Total: 2000000, DepositTotal: 100000, DepositorAddress: buyer, IsDeposited: true},
+		&models.MarketplaceCFT20Detail{ListingID: 3, TokenID: 1, Amount: models.NewAmount(1000000), PPT: 2000000},
+		&models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST", Decimals: 6, MaxSupply: models.NewAmount(21000000000000)},
+		&models.Status{ChainID: "cosmoshub-4", BaseTokenUSD: 10},
+	)
+	// The average price query fails
+	err := db.Callback().Row().Replace("gorm:row", func(tx *gorm.DB) {
+		tx.AddError(errors.New("canceling statement due to statement timeout"))
+	})
+	if err != nil {
+		t.Fatalf("unable to replace row callback: %v", err)
+	}
+	err = db.Callback().Update().After("gorm:update").Register("test:rows_affected", func(tx *gorm.DB) {
+		tx.RowsAffected = 1
+	})
+	if err != nil {
+		t.Fatalf("unable to register update callback: %v", err)
+	}
+	var statements []string
+	err = db.Callback().Raw().After("gorm:raw").Register("test:statements", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("unable to register raw callback: %v", err)
+	}
+	observer := &recordingBalanceObserver{}
+	protocol := &Marketplace{chainID: "cosmoshub-4", version: "v1", virtualAddress: "marketplace", tradeFee: 0.02, db: db, balanceObserver: observer, amountPrecision: AmountPrecisionReject}
+
+	protocolURN := parseTestURN(t, "urn:marketplace:cosmoshub-4@v1;buy.cft20$h=LISTING")
+	err = protocol.Process(models.Transaction{ID: 1, Height: 100}, protocolURN, testTransaction(t, buyer, seller, 1900000))
+	if err != nil {
+		t.Fatalf("expected buy to succeed when the token statistics fail, got %v", err)
+	}
+	if pool.Commits != 1 || pool.Rollbacks != 0 {
+		t.Errorf("expected the buy to be committed, got %d commits and %d rollbacks", pool.Commits, pool.Rollbacks)
+	}
+	// On Postgres a failed statement aborts the transaction unless it is
+	// rolled back to a savepoint
+	rolledBack := 0
+	for _, statement := range statements {
+		if strings.HasPrefix(statement, "ROLLBACK TO SAVEPOINT") {
+			rolledBack++
+		}
+	}
+	if rolledBack != 1 {
+		t.Errorf("expected the token statistics to be rolled back to a savepoint, got %v", statements)
+	}
+	if len(observer.changes) != 1 || observer.changes[0] != (balanceChange{buyer, "0", "1000000"}) {
+		t.Errorf("expected the buyer to receive the tokens, got %+v", observer.changes)
+	}
+}
+
+func TestProcessFrozenTokenListing(t *testing.T) {
+	seller := "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95"
+	buyer := "cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"
+	tests := []struct {
+		operation  string
+		sender     string
+		amountSent uint64
+	}{
+		{"list.cft20$tic=TEST,amt=10,ppt=1,mindep=0.1,to=100", seller, 1000000},
+		{"buy.cft20$h=LISTING", buyer, 1900000},
+		{"delist$h=LISTING", seller, 0},
+	}
+
+	for _, frozen := range []bool{false, true} {
+		for _, test := range tests {
+			var statements []string
+			db := newRowsAffectedDB(t, 1, &statements)
+			dbtest.StubQueries(t, db,
+				&models.Transaction{ID: 2, Hash: "LISTING"},
+				&models.MarketplaceListing{ID: 3, ChainID: "cosmoshub-4", TransactionID: 2, SellerAddress: seller, Total: 2000000, DepositTotal: 100000, DepositorAddress: buyer, IsDeposited: true},
+				&models.MarketplaceCFT20Detail{ListingID: 3, TokenID: 1, Amount: models.NewAmount(1000000), PPT: 2000000},
+				&models.Token{ID: 1, ChainID: "cosmoshub-4", Ticker: "TEST", Decimals: 6, MaxSupply: models.NewAmount(21000000000000), IsFrozen: frozen},
+				&models.TokenHolder{ID: 1, ChainID: "cosmoshub-4", TokenID: 1, Address: seller, Amount: models.NewAmount(100000000)},
+				&models.Status{ChainID: "cosmoshub-4", BaseTokenUSD: 10},
+			)
+			protocol := &Marketplace{
+				chainID:              "cosmoshub-4",
+				version:              "v1",
+				virtualAddress:       "marketplace",
+				minimumTimeoutBlocks: 50,
+				minimumDeposit:       0.01,
+				minimumTradeSize:     1,
+				tradeFee:             0.02,
+				amountPrecision:      AmountPrecisionReject,
+				amountMaxDecimals:    defaultAmountMaxDecimals,
+				db:                   db,
+				balanceObserver:      noopBalanceObserver{},
+			}
+
+			protocolURN := parseTestURN(t, "urn:marketplace:cosmoshub-4@v1;"+test.operation)
+			err := protocol.Process(models.Transaction{ID: 1, Height: 100}, protocolURN, testTransaction(t, test.sender, seller, test.amountSent))
+			if !frozen && err != nil {
+				t.Errorf("expected '%s' to succeed, got %v", test.operation, err)
+			}
+			if frozen && (!errors.Is(err, ErrInvalidOperation) || !strings.Contains(err.Error(), "is frozen")) {
+				t.Errorf("expected '%s' to be rejected for a frozen token, got %v", test.operation, err)
+			}
+		}
+	}
+}
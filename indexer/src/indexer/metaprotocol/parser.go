@@ -1,7 +1,7 @@
 package metaprotocol
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/leodido/go-urn"
@@ -14,20 +14,24 @@ type ProtocolURN struct {
 	KeyValuePairs map[string]string
 }
 
-func ParseProtocolString(protocolURN *urn.URN) (ProtocolURN, error) {
+// ParseProtocolString parses the protocol specific string of protocolURN.
+// When a key is given more than once the last value applies, unless
+// rejectDuplicateKeys is set. Memos indexed before duplicates were rejected
+// rely on the last value, so reindexing them needs it unset
+func ParseProtocolString(protocolURN *urn.URN, rejectDuplicateKeys bool) (ProtocolURN, error) {
 	var parsedProtocolURN ProtocolURN
 	// We need to parse the protocol specific string in SS, it contains
 	// {chainId}@{version};operation$h={unique hash of content}
 	// cosmoshub-4@v1beta;inscribe$h=c4749f95902411d1a45a033d8a6b3e6aa0de0a0028fe8737f66fed6834dce8bf
 	sourceContent := strings.Split(protocolURN.SS, ";")
 	if len(sourceContent) != 2 {
-		return parsedProtocolURN, fmt.Errorf("invalid source/content split: %s", protocolURN.SS)
+		return parsedProtocolURN, invalidOperationf("invalid source/content split: %s", protocolURN.SS)
 	}
 
 	// Parse cosmoshub-4@v1beta
 	sourceVersioning := strings.Split(sourceContent[0], "@")
 	if len(sourceVersioning) != 2 {
-		return parsedProtocolURN, fmt.Errorf("incorrect source versioning parts: %s", protocolURN.SS)
+		return parsedProtocolURN, invalidOperationf("incorrect source versioning parts: %s", protocolURN.SS)
 	}
 	parsedProtocolURN.ChainID = sourceVersioning[0]
 	parsedProtocolURN.Version = sourceVersioning[1]
@@ -35,7 +39,7 @@ func ParseProtocolString(protocolURN *urn.URN) (ProtocolURN, error) {
 	// Parse inscribe$h=...contenthash...
 	opContent := strings.Split(sourceContent[1], "$")
 	if len(opContent) != 2 {
-		return parsedProtocolURN, fmt.Errorf("invalid op/content parts: %s", protocolURN.SS)
+		return parsedProtocolURN, invalidOperationf("invalid op/content parts: %s", protocolURN.SS)
 	}
 	parsedProtocolURN.Operation = opContent[0]
 
@@ -46,7 +50,10 @@ func ParseProtocolString(protocolURN *urn.URN) (ProtocolURN, error) {
 	for _, keyValuePair := range keyValuePairsString {
 		keyValue := strings.Split(keyValuePair, "=")
 		if len(keyValue) != 2 {
-			return parsedProtocolURN, fmt.Errorf("invalid key/value pair: %s", protocolURN.SS)
+			return parsedProtocolURN, invalidOperationf("invalid key/value pair: %s", protocolURN.SS)
+		}
+		if _, exists := keyValuePairs[keyValue[0]]; exists && rejectDuplicateKeys {
+			return parsedProtocolURN, invalidOperationf("duplicate key '%s': %s", keyValue[0], protocolURN.SS)
 		}
 		keyValuePairs[keyValue[0]] = keyValue[1]
 	}
@@ -54,3 +61,20 @@ func ParseProtocolString(protocolURN *urn.URN) (ProtocolURN, error) {
 
 	return parsedProtocolURN, nil
 }
+
+// CheckExpiry rejects the operation if it has an exp key and height is past
+// the expiry height it contains. Operations without exp never expire
+func (parsedURN ProtocolURN) CheckExpiry(height uint64) error {
+	expiryString, ok := parsedURN.KeyValuePairs["exp"]
+	if !ok {
+		return nil
+	}
+	expiry, err := strconv.ParseUint(expiryString, 10, 64)
+	if err != nil {
+		return invalidOperationf("invalid expiry height '%s'", expiryString)
+	}
+	if height > expiry {
+		return invalidOperationf("operation expired at height %d", expiry)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package metaprotocol
+
+import (
+	"testing"
+
+	"github.com/leodido/go-urn"
+)
+
+func parseTestURN(t *testing.T, memo string) *urn.URN {
+	protocolURN, ok := urn.Parse([]byte(memo))
+	if !ok {
+		t.Fatalf("invalid test URN '%s'", memo)
+	}
+	return protocolURN
+}
+
+func TestParseProtocolString(t *testing.T) {
+	protocolURN := parseTestURN(t, "urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10,dst=cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95")
+	parsedURN, err := ParseProtocolString(protocolURN, true)
+	if err != nil {
+		t.Fatalf("expected URN to parse, got %v", err)
+	}
+	if parsedURN.ChainID != "cosmoshub-4" {
+		t.Errorf("expected cosmoshub-4, got %s", parsedURN.ChainID)
+	}
+	if parsedURN.Version != "v1" {
+		t.Errorf("expected v1, got %s", parsedURN.Version)
+	}
+	if parsedURN.Operation != "transfer" {
+		t.Errorf("expected transfer, got %s", parsedURN.Operation)
+	}
+	if parsedURN.KeyValuePairs["amt"] != "10" {
+		t.Errorf("expected amt 10, got %s", parsedURN.KeyValuePairs["amt"])
+	}
+}
+
+func TestParseProtocolStringDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		memo     string
+		key      string
+		expected string
+	}{
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=1,amt=1000000,dst=cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95", "amt", "1000000"},
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=1,dst=cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95,dst=cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92", "dst", "cosmos198tjtk9rzwzwcce6r8uu2gj950p9gqakm6jz92"},
+	}
+	for _, test := range tests {
+		_, err := ParseProtocolString(parseTestURN(t, test.memo), true)
+		if err == nil {
+			t.Errorf("expected duplicate keys to be rejected in '%s'", test.memo)
+		}
+
+		// Without strict keys the last value applies, as it always has
+		parsedURN, err := ParseProtocolString(parseTestURN(t, test.memo), false)
+		if err != nil {
+			t.Errorf("expected duplicate keys to be accepted in '%s', got %v", test.memo, err)
+			continue
+		}
+		if parsedURN.KeyValuePairs[test.key] != test.expected {
+			t.Errorf("expected %s to be '%s', got '%s'", test.key, test.expected, parsedURN.KeyValuePairs[test.key])
+		}
+	}
+}
+
+func TestCheckExpiry(t *testing.T) {
+	tests := []struct {
+		memo   string
+		height uint64
+		valid  bool
+	}{
+		// Without exp operations never expire
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10", 1000, true},
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10,exp=1000", 999, true},
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10,exp=1000", 1000, true},
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10,exp=1000", 1001, false},
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10,exp=-1", 1, false},
+		{"urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=10,exp=", 1, false},
+	}
+	for _, test := range tests {
+		parsedURN, err := ParseProtocolString(parseTestURN(t, test.memo), false)
+		if err != nil {
+			t.Fatalf("expected URN to parse, got %v", err)
+		}
+		err = parsedURN.CheckExpiry(test.height)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' to be valid at height %d, got %s", test.memo, test.height, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' to be expired at height %d", test.memo, test.height)
+		}
+	}
+}
@@ -8,5 +8,13 @@ import (
 
 type Processor interface {
 	Name() string
+	// Version returns the version of the processor implementation, which is
+	// separate from the metaprotocol version in the URN
+	Version() string
+	// Process applies the operation in protocolURN. It must store either all
+	// of its changes or none of them, as the indexer may call it again for the
+	// same transaction after an error. Errors for operations that break the
+	// metaprotocol rules must match ErrInvalidOperation, by default the
+	// indexer halts on any other error
 	Process(transactionModel models.Transaction, protocolURN *urn.URN, rawTransaction types.RawTransaction) error
 }
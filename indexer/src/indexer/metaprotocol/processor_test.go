@@ -0,0 +1,84 @@
+package metaprotocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/models"
+	"github.com/donovansolms/cosmos-inscriptions/indexer/src/indexer/types"
+	"gorm.io/gorm"
+)
+
+func TestProcessorVersions(t *testing.T) {
+	semver := regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+	processors := []Processor{
+		&Inscription{},
+		&CFT20{},
+		&Marketplace{},
+	}
+	for _, processor := range processors {
+		if !semver.MatchString(processor.Version()) {
+			t.Errorf("expected %s version to be semver, got '%s'", processor.Name(), processor.Version())
+		}
+	}
+}
+
+// testTransaction returns a transaction from sender that sends amount uatom
+// to receiver
+func testTransaction(t *testing.T, sender string, receiver string, amount uint64) types.RawTransaction {
+	var rawTransaction types.RawTransaction
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s","amount":[{"denom":"uatom","amount":"%d"}]}]}}`, sender, receiver, amount)), &rawTransaction)
+	if err != nil {
+		t.Fatalf("invalid test transaction: %v", err)
+	}
+	return rawTransaction
+}
+
+// testTransactionCount makes the hashes of test transactions unique
+var testTransactionCount int
+
+// createTestTransaction stores a transaction at height for operations
+// processed against a Postgres test database
+func createTestTransaction(t *testing.T, db *gorm.DB, height uint64) models.Transaction {
+	testTransactionCount++
+	transactionModel := models.Transaction{
+		Height:        height,
+		Hash:          fmt.Sprintf("TEST%060d", testTransactionCount),
+		Content:       "{}",
+		Fees:          "[]",
+		DateCreated:   time.Unix(1700000000, 0).Add(time.Duration(height) * time.Second),
+		StatusMessage: types.TransactionStatePending,
+	}
+	if err := db.Create(&transactionModel).Error; err != nil {
+		t.Fatalf("unable to create test transaction: %v", err)
+	}
+	return transactionModel
+}
+
+// createTestToken stores a token with ticker, 6 decimals and a mint limit of
+// 1000 in a Postgres test database
+func createTestToken(t *testing.T, db *gorm.DB, ticker string) models.Token {
+	transactionModel := createTestTransaction(t, db, 1)
+	tokenModel := models.Token{
+		ChainID:       "cosmoshub-4",
+		Height:        transactionModel.Height,
+		Version:       "v1",
+		TransactionID: transactionModel.ID,
+		Creator:       "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95",
+		CurrentOwner:  "cosmos1fkwcez7yzjz2gpzhesdsd3r5wp0w82cftr4k95",
+		Name:          ticker,
+		Ticker:        ticker,
+		Decimals:      6,
+		MaxSupply:     models.NewAmount(21000000000000),
+		PerMintLimit:  models.NewAmount(1000000000),
+		MintPage:      "default",
+		DateCreated:   transactionModel.DateCreated,
+	}
+	if err := db.Create(&tokenModel).Error; err != nil {
+		t.Fatalf("unable to create test token: %v", err)
+	}
+	return tokenModel
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+)
+
+// Amount is a token amount in base units. Token amounts are stored in
+// numeric columns and may exceed the uint64 range, so Amount is backed by a
+// big.Int. The zero value is 0 and operations return a new Amount instead of
+// changing the receiver
+type Amount struct {
+	value *big.Int
+}
+
+// NewAmount returns value as an Amount
+func NewAmount(value uint64) Amount {
+	return Amount{value: new(big.Int).SetUint64(value)}
+}
+
+// NewAmountFromBigInt returns a copy of value as an Amount
+func NewAmountFromBigInt(value *big.Int) Amount {
+	return Amount{value: new(big.Int).Set(value)}
+}
+
+// ParseAmount parses a base 10 integer amount, such as stored in a numeric
+// column
+func ParseAmount(value string) (Amount, error) {
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount '%s'", value)
+	}
+	return Amount{value: parsed}, nil
+}
+
+// BigInt returns a copy of the amount as a big.Int
+func (a Amount) BigInt() *big.Int {
+	if a.value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.value)
+}
+
+// Add returns a + b
+func (a Amount) Add(b Amount) Amount {
+	return Amount{value: new(big.Int).Add(a.BigInt(), b.BigInt())}
+}
+
+// Sub returns a - b. Amounts can't be negative, callers check that b is not
+// larger than a first
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{value: new(big.Int).Sub(a.BigInt(), b.BigInt())}
+}
+
+// QuoUint64 returns a / b, rounded down
+func (a Amount) QuoUint64(b uint64) Amount {
+	return Amount{value: new(big.Int).Quo(a.BigInt(), new(big.Int).SetUint64(b))}
+}
+
+// Cmp returns -1, 0 or +1 if a is less than, equal to or greater than b
+func (a Amount) Cmp(b Amount) int {
+	return a.BigInt().Cmp(b.BigInt())
+}
+
+// LT returns true if a is less than b
+func (a Amount) LT(b Amount) bool {
+	return a.Cmp(b) < 0
+}
+
+// GT returns true if a is greater than b
+func (a Amount) GT(b Amount) bool {
+	return a.Cmp(b) > 0
+}
+
+// IsZero returns true if the amount is 0
+func (a Amount) IsZero() bool {
+	return a.value == nil || a.value.Sign() == 0
+}
+
+// Float64 returns the nearest float64 to the amount, for price calculations
+func (a Amount) Float64() float64 {
+	value, _ := new(big.Float).SetInt(a.BigInt()).Float64()
+	return value
+}
+
+func (a Amount) String() string {
+	return a.BigInt().String()
+}
+
+// Scan reads an amount from a numeric or integer column
+func (a *Amount) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case int64:
+		a.value = big.NewInt(value)
+		return nil
+	case string:
+		return a.parse(value)
+	case []byte:
+		return a.parse(string(value))
+	}
+	return fmt.Errorf("unable to scan %T into an amount", src)
+}
+
+func (a *Amount) parse(value string) error {
+	parsed, err := ParseAmount(value)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value stores the amount as a numeric string. Negative amounts are rejected
+// so an unchecked Sub can't be stored
+func (a Amount) Value() (driver.Value, error) {
+	if a.value != nil && a.value.Sign() < 0 {
+		return nil, fmt.Errorf("amount %s is negative", a.value)
+	}
+	return a.String(), nil
+}
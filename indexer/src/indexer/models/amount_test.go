@@ -0,0 +1,45 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAmountArithmetic(t *testing.T) {
+	max := NewAmount(math.MaxUint64)
+	one := NewAmount(1)
+
+	sum := max.Add(one)
+	if sum.String() != "18446744073709551616" {
+		t.Errorf("expected MaxUint64 + 1 not to overflow, got %s", sum)
+	}
+	if !max.LT(sum) || !sum.GT(max) {
+		t.Errorf("expected %s to be less than %s", max, sum)
+	}
+	if sum.Sub(one).Cmp(max) != 0 {
+		t.Errorf("expected %s - 1 to be %s, got %s", sum, max, sum.Sub(one))
+	}
+	if sum.QuoUint64(2).String() != "9223372036854775808" {
+		t.Errorf("expected %s / 2 to be 2^63, got %s", sum, sum.QuoUint64(2))
+	}
+
+	// Operations don't change their operands
+	if max.String() != "18446744073709551615" || one.String() != "1" {
+		t.Errorf("expected operands to be unchanged, got %s and %s", max, one)
+	}
+
+	var zero Amount
+	if !zero.IsZero() || zero.String() != "0" || !zero.LT(one) {
+		t.Errorf("expected the zero value to be 0, got %s", zero)
+	}
+}
+
+func TestAmountValueRejectsNegative(t *testing.T) {
+	value, err := NewAmount(5).Value()
+	if err != nil || value != "5" {
+		t.Errorf("expected 5 to be stored as '5', got %v (%v)", value, err)
+	}
+	if _, err := NewAmount(1).Sub(NewAmount(2)).Value(); err == nil {
+		t.Errorf("expected a negative amount to be rejected")
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Block struct {
+	ID          uint64    `gorm:"primary_key"`
+	ChainID     string    `gorm:"column:chain_id"`
+	Height      uint64    `gorm:"column:height"`
+	Hash        string    `gorm:"column:hash"`
+	DateCreated time.Time `gorm:"column:date_created"`
+}
+
+func (Block) TableName() string {
+	return "block"
+}
@@ -19,7 +19,9 @@ type Inscription struct {
 	Metadata         datatypes.JSON `gorm:"column:metadata"`
 	ContentPath      string         `gorm:"column:content_path"`
 	ContentSizeBytes uint64         `gorm:"column:content_size_bytes"`
-	DateCreated      time.Time      `gorm:"column:date_created"`
+	// CollectionID is the inscription this inscription belongs to, if any
+	CollectionID *uint64   `gorm:"column:collection_id"`
+	DateCreated  time.Time `gorm:"column:date_created"`
 }
 
 func (Inscription) TableName() string {
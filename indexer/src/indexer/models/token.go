@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 type Token struct {
@@ -17,19 +18,46 @@ type Token struct {
 	Name              string         `gorm:"column:name"`
 	Ticker            string         `gorm:"column:ticker"`
 	Decimals          uint64         `gorm:"column:decimals"`
-	MaxSupply         uint64         `gorm:"column:max_supply"`
-	PerMintLimit      uint64         `gorm:"column:per_mint_limit"`
+	MaxSupply         Amount         `gorm:"column:max_supply"`
+	PerMintLimit      Amount         `gorm:"column:per_mint_limit"`
 	LaunchTimestamp   uint64         `gorm:"column:launch_timestamp"`
 	MintPage          string         `gorm:"column:mint_page"`
 	Metadata          datatypes.JSON `gorm:"column:metadata"`
 	ContentPath       string         `gorm:"column:content_path"`
 	ContentSizeBytes  uint64         `gorm:"column:content_size_bytes"`
-	CirculatingSupply uint64         `gorm:"column:circulating_supply"`
+	CirculatingSupply Amount         `gorm:"column:circulating_supply"`
 	LastPriceBase     uint64         `gorm:"column:last_price_base"`
 	Volume24Base      uint64         `gorm:"column:volume_24_base"`
+	IsFrozen          bool           `gorm:"column:is_frozen"`
+	AmountPrecision   string         `gorm:"column:amount_precision"`
 	DateCreated       time.Time      `gorm:"column:date_created"`
+	DateDeleted       gorm.DeletedAt `gorm:"column:date_deleted"`
 }
 
 func (Token) TableName() string {
 	return "token"
 }
+
+// IsRetired returns true if the token has been soft-deleted. Retired tokens
+// keep their history but can no longer be used
+func (t Token) IsRetired() bool {
+	return t.DateDeleted.Valid
+}
+
+// RemainingSupply returns the amount of the token that can still be minted
+func (t Token) RemainingSupply() Amount {
+	if !t.CirculatingSupply.LT(t.MaxSupply) {
+		return Amount{}
+	}
+	return t.MaxSupply.Sub(t.CirculatingSupply)
+}
+
+// NextMintAmount returns the amount the next mint receives, which is the per
+// mint limit unless less than that remains
+func (t Token) NextMintAmount() Amount {
+	remaining := t.RemainingSupply()
+	if t.PerMintLimit.GT(remaining) {
+		return remaining
+	}
+	return t.PerMintLimit
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type TokenAddressHistory struct {
 	ID            uint64    `gorm:"primary_key"`
@@ -11,10 +16,20 @@ type TokenAddressHistory struct {
 	Sender        string    `gorm:"column:sender"`
 	Receiver      string    `gorm:"column:receiver"`
 	Action        string    `gorm:"column:action"`
-	Amount        uint64    `gorm:"column:amount"`
+	Amount        Amount    `gorm:"column:amount"`
 	DateCreated   time.Time `gorm:"column:date_created"`
 }
 
 func (TokenAddressHistory) TableName() string {
 	return "token_address_history"
 }
+
+// BeforeSave rejects history rows that don't move any tokens. Every action
+// recorded in the token history moves a non-zero amount, so a zero amount
+// means a bug in the code path writing it
+func (history *TokenAddressHistory) BeforeSave(tx *gorm.DB) error {
+	if history.Amount.IsZero() {
+		return errors.New("token history amount must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package models
+
+import "testing"
+
+func TestTokenAddressHistoryBeforeSave(t *testing.T) {
+	history := TokenAddressHistory{
+		Action: "transfer",
+	}
+	if err := history.BeforeSave(nil); err == nil {
+		t.Errorf("expected zero amount history to be rejected")
+	}
+
+	history.Amount = NewAmount(1)
+	if err := history.BeforeSave(nil); err != nil {
+		t.Errorf("expected non-zero amount history to be accepted, got %v", err)
+	}
+}
@@ -7,7 +7,7 @@ type TokenHolder struct {
 	ChainID     string    `gorm:"column:chain_id"`
 	TokenID     uint64    `gorm:"column:token_id"`
 	Address     string    `gorm:"column:address"`
-	Amount      uint64    `gorm:"column:amount"`
+	Amount      Amount    `gorm:"column:amount"`
 	DateUpdated time.Time `gorm:"column:date_updated"`
 }
 
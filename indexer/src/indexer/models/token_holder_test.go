@@ -0,0 +1,48 @@
+package models
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestTokenHolderAmountNumericRoundTrip(t *testing.T) {
+	// Amounts are stored in numeric columns and may exceed the uint64 range
+	aboveUint64, _ := new(big.Int).SetString("340282366920938463463374607431768211456", 10) // 2^128
+	amounts := []Amount{
+		{},
+		NewAmount(math.MaxInt64),
+		NewAmount(math.MaxInt64 + 1),
+		NewAmount(math.MaxUint64),
+		NewAmount(math.MaxUint64).Add(NewAmount(1)),
+		NewAmountFromBigInt(aboveUint64),
+	}
+	typeMap := pgtype.NewMap()
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, amount := range amounts {
+			holder := TokenHolder{Amount: amount}
+			encoded, err := typeMap.Encode(pgtype.NumericOID, format, holder.Amount, nil)
+			if err != nil {
+				t.Errorf("expected %s to encode in format %d, got %v", amount, format, err)
+				continue
+			}
+			var stored TokenHolder
+			err = typeMap.Scan(pgtype.NumericOID, format, encoded, &stored.Amount)
+			if err != nil {
+				t.Errorf("expected %s to decode in format %d, got %v", amount, format, err)
+				continue
+			}
+			if stored.Amount.Cmp(amount) != 0 {
+				t.Errorf("expected %s to round trip in format %d, got %s", amount, format, stored.Amount)
+			}
+		}
+	}
+
+	// Amounts written while the columns were int8 are scanned as well
+	var stored TokenHolder
+	if err := stored.Amount.Scan(int64(42)); err != nil || stored.Amount.Cmp(NewAmount(42)) != 0 {
+		t.Errorf("expected int8 amount to scan as 42, got %s (%v)", stored.Amount, err)
+	}
+}
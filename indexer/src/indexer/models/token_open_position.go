@@ -8,7 +8,7 @@ type TokenOpenPosition struct {
 	TransactionID uint64    `gorm:"column:transaction_id"`
 	TokenID       uint64    `gorm:"column:token_id"`
 	SellerAddress string    `gorm:"column:seller_address"`
-	Amount        uint64    `gorm:"column:amount"` // Amount of TokenID listed
+	Amount        Amount    `gorm:"column:amount"` // Amount of TokenID listed
 	PPT           uint64    `gorm:"column:ppt"`    // PPT = Price Per Token, in uatom
 	Total         uint64    `gorm:"column:total"`  // Amount * PPT
 	IsFilled      bool      `gorm:"column:is_filled"`
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestTokenRemainingSupply(t *testing.T) {
+	token := Token{
+		MaxSupply:    NewAmount(1000),
+		PerMintLimit: NewAmount(400),
+	}
+
+	// Mint until the supply runs out, the last mint receives what is left
+	expected := []struct {
+		mintAmount uint64
+		remaining  uint64
+	}{
+		{400, 600},
+		{400, 200},
+		{200, 0},
+		{0, 0},
+	}
+	for _, test := range expected {
+		mintAmount := token.NextMintAmount()
+		if mintAmount.Cmp(NewAmount(test.mintAmount)) != 0 {
+			t.Errorf("expected mint amount %d, got %s", test.mintAmount, mintAmount)
+		}
+		token.CirculatingSupply = token.CirculatingSupply.Add(mintAmount)
+		if token.RemainingSupply().Cmp(NewAmount(test.remaining)) != 0 {
+			t.Errorf("expected remaining supply %d, got %s", test.remaining, token.RemainingSupply())
+		}
+	}
+}
+
+func TestTokenIsRetired(t *testing.T) {
+	var token Token
+	if token.IsRetired() {
+		t.Errorf("expected token without a delete date to be active")
+	}
+	token.DateDeleted = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	if !token.IsRetired() {
+		t.Errorf("expected soft-deleted token to be retired")
+	}
+}
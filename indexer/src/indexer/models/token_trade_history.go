@@ -10,7 +10,7 @@ type TokenTradeHistory struct {
 	SellerAddress string    `gorm:"column:seller_address"`
 	BuyerAddress  string    `gorm:"column:buyer_address"`
 	AmountQuote   uint64    `gorm:"column:amount_quote"` // Amount of TokenID
-	AmountBase    uint64    `gorm:"column:amount_base"`  // Amount of ATOM
+	AmountBase    Amount    `gorm:"column:amount_base"`  // Amount of ATOM
 	Rate          uint64    `gorm:"column:rate"`         // Amount of ATOM per TokenID
 	TotalUSD      float64   `gorm:"column:total_usd"`    // Amount of USD
 	DateCreated   time.Time `gorm:"column:date_created"`
@@ -0,0 +1,109 @@
+package indexer
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PhaseTimer receives the time spent in each phase of processing a
+// transaction. Database phases are named after the operation and table, such
+// as "query:token" or "update:token_holder"
+type PhaseTimer interface {
+	OnPhase(phase string, duration time.Duration)
+}
+
+// phaseStartKey is the gorm instance key holding the start of a statement
+const phaseStartKey = "phase_timer:start"
+
+// noopPhaseDone is returned by startPhase when no timer is set so that
+// disabled timing doesn't allocate or read the clock
+func noopPhaseDone() {}
+
+// SetPhaseTimer enables timing of processing phases, replacing any timer set
+// before. Timing is disabled unless a timer is set. It must be called before
+// Run, the timer is read without locking while blocks are indexed
+func (i *Indexer) SetPhaseTimer(timer PhaseTimer) error {
+	if timer == nil {
+		return errors.New("phase timer can't be nil")
+	}
+	// The database callbacks look up the current timer, so they only need
+	// to be registered the first time
+	if i.phaseTimer == nil {
+		err := registerPhaseCallbacks(i.db, i.onPhase)
+		if err != nil {
+			return err
+		}
+	}
+	i.phaseTimer = timer
+	return nil
+}
+
+// onPhase reports duration to the current timer, if any
+func (i *Indexer) onPhase(phase string, duration time.Duration) {
+	if i.phaseTimer != nil {
+		i.phaseTimer.OnPhase(phase, duration)
+	}
+}
+
+// startPhase starts timing phase and returns a function that reports the
+// duration when called
+func (i *Indexer) startPhase(phase string) func() {
+	if i.phaseTimer == nil {
+		return noopPhaseDone
+	}
+	start := time.Now()
+	return func() {
+		i.phaseTimer.OnPhase(phase, time.Since(start))
+	}
+}
+
+// registerPhaseCallbacks times every database statement made through db,
+// which covers the lookups, balance updates and history writes of the
+// processors sharing it
+func registerPhaseCallbacks(db *gorm.DB, onPhase func(phase string, duration time.Duration)) error {
+	before := func(db *gorm.DB) {
+		db.InstanceSet(phaseStartKey, time.Now())
+	}
+	after := func(operation string) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			start, ok := db.InstanceGet(phaseStartKey)
+			if !ok {
+				return
+			}
+			onPhase(operation+":"+db.Statement.Table, time.Since(start.(time.Time)))
+		}
+	}
+
+	callbacks := db.Callback()
+	err := callbacks.Query().Before("gorm:query").Register("phase_timer:before_query", before)
+	if err != nil {
+		return err
+	}
+	err = callbacks.Query().After("gorm:query").Register("phase_timer:after_query", after("query"))
+	if err != nil {
+		return err
+	}
+	err = callbacks.Create().Before("gorm:create").Register("phase_timer:before_create", before)
+	if err != nil {
+		return err
+	}
+	err = callbacks.Create().After("gorm:create").Register("phase_timer:after_create", after("create"))
+	if err != nil {
+		return err
+	}
+	err = callbacks.Update().Before("gorm:update").Register("phase_timer:before_update", before)
+	if err != nil {
+		return err
+	}
+	err = callbacks.Update().After("gorm:update").Register("phase_timer:after_update", after("update"))
+	if err != nil {
+		return err
+	}
+	err = callbacks.Delete().Before("gorm:delete").Register("phase_timer:before_delete", before)
+	if err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("phase_timer:after_delete", after("delete"))
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// Secp256k1PubKeyType is the type of the public keys VerifySigner can derive
+// an address from
+const Secp256k1PubKeyType = "/cosmos.crypto.secp256k1.PubKey"
+
+// VerifySigner checks that the transaction is well-formed and that the sender
+// returned by GetSenderAddress is the address of the first signer's public
+// key. The signature itself can not be verified as the signed bytes are not
+// part of the decoded transaction
+func (tx RawTransaction) VerifySigner() error {
+	signerInfos := tx.AuthInfo.SignerInfos
+	if len(signerInfos) == 0 {
+		return errors.New("transaction has no signers")
+	}
+	if len(tx.Signatures) != len(signerInfos) {
+		return fmt.Errorf("expected %d signatures, got %d", len(signerInfos), len(tx.Signatures))
+	}
+	for i, signature := range tx.Signatures {
+		signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil || len(signatureBytes) == 0 {
+			return fmt.Errorf("invalid signature %d", i)
+		}
+	}
+
+	publicKey := signerInfos[0].PublicKey
+	if publicKey.Type != Secp256k1PubKeyType {
+		return fmt.Errorf("unsupported public key type '%s'", publicKey.Type)
+	}
+	keyBytes, err := base64.StdEncoding.DecodeString(publicKey.Key)
+	if err != nil || len(keyBytes) != 33 {
+		return errors.New("invalid signer public key")
+	}
+
+	sender, err := tx.GetSenderAddress()
+	if err != nil {
+		return err
+	}
+	_, senderBytes, err := bech32.DecodeAndConvert(sender)
+	if err != nil {
+		return fmt.Errorf("invalid sender address '%s'", err)
+	}
+
+	signerKey := secp256k1.PubKey{Key: keyBytes}
+	if !bytes.Equal(signerKey.Address(), senderBytes) {
+		return fmt.Errorf("sender '%s' does not match the signer", sender)
+	}
+	return nil
+}
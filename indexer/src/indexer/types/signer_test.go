@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func newSignedTransaction(t *testing.T, sender string) RawTransaction {
+	raw := fmt.Sprintf(`{
+		"body": {"messages": [{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "%s"}]},
+		"auth_info": {"signer_infos": [{"public_key": {
+			"@type": "/cosmos.crypto.secp256k1.PubKey",
+			"key": "ApaeH0tjVMGWBjt46azmezqcacz3YGrhpBMFQ9IG4UoC"
+		}}]},
+		"signatures": ["c2lnbmF0dXJl"]
+	}`, sender)
+	var tx RawTransaction
+	if err := json.Unmarshal([]byte(raw), &tx); err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+	return tx
+}
+
+func TestVerifySigner(t *testing.T) {
+	// The sender is the address of the signer's public key
+	tx := newSignedTransaction(t, "cosmos1whur0gjzsx5xrw4kd5k2fsudnpa5y7s4e4cw9y")
+	if err := tx.VerifySigner(); err != nil {
+		t.Errorf("expected valid transaction, got %s", err)
+	}
+}
+
+func TestVerifySignerTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(tx *RawTransaction)
+	}{
+		{"other sender", func(tx *RawTransaction) {
+			tx.Body.Messages[0].FromAddress = "cosmos17lt690uuhx8h4fp4pfe7ms3vxjl4xvfz8837yn"
+		}},
+		{"other public key", func(tx *RawTransaction) {
+			tx.AuthInfo.SignerInfos[0].PublicKey.Key = "A5aeH0tjVMGWBjt46azmezqcacz3YGrhpBMFQ9IG4UoC"
+		}},
+		{"missing signature", func(tx *RawTransaction) {
+			tx.Signatures = nil
+		}},
+		{"invalid signature", func(tx *RawTransaction) {
+			tx.Signatures = []string{"not base64!"}
+		}},
+		{"unsupported key type", func(tx *RawTransaction) {
+			tx.AuthInfo.SignerInfos[0].PublicKey.Type = "/cosmos.crypto.ed25519.PubKey"
+		}},
+	}
+	for _, test := range tests {
+		tx := newSignedTransaction(t, "cosmos1whur0gjzsx5xrw4kd5k2fsudnpa5y7s4e4cw9y")
+		test.tamper(&tx)
+		if err := tx.VerifySigner(); err == nil {
+			t.Errorf("%s: expected tampered transaction to be rejected", test.name)
+		}
+	}
+}
@@ -17,6 +17,16 @@ const InscriptionTypeContentNFT = "inscriptions.v1.content.nft"
 const InscriptionTypeContentNFTCollection = "inscriptions.v1.content.nft.collection"
 const InscriptionTypeMultipart = "inscriptions.v1.multipart"
 
+// Sources a metaprotocol URN can be read from
+const URNSourceMemo = "memo"
+const URNSourceMessageMemo = "message_memo"
+
+// Message types that can carry a metaprotocol memo
+const MsgSendType = "/cosmos.bank.v1beta1.MsgSend"
+const MsgExecuteContractType = "/cosmwasm.wasm.v1.MsgExecuteContract"
+const MsgTransferType = "/ibc.applications.transfer.v1.MsgTransfer"
+const MsgExecType = "/cosmos.authz.v1beta1.MsgExec"
+
 const TransactionStatePending = "pending"
 const TransactionStateSuccess = "success"
 const TransactionStateError = "error: "
@@ -172,6 +182,8 @@ type RawTransaction struct {
 				Amount string `json:"amount"`
 				Denom  string `json:"denom"`
 			} `json:"token"`
+			Memo    string `json:"memo"`
+			Grantee string `json:"grantee"`
 		} `json:"messages"`
 		Memo                        string         `json:"memo"`
 		TimeoutHeight               string         `json:"timeout_height"`
@@ -287,6 +299,9 @@ type RawMsgRevoke struct {
 }
 
 type Metadata struct {
+	// Type is the kind of inscription, such as
+	// InscriptionTypeContentNFTCollection. It is empty for plain content
+	Type        string `json:"@type,omitempty"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Mime        string `json:"mime"`
@@ -336,20 +351,73 @@ func (tx RawTransaction) GetTxByteSize() int {
 	return len(jsonBytes)
 }
 
+// GetSenderAddress returns the signer of the first message that has one. The
+// memo applies to the whole transaction, so the message type carrying it
+// only determines which field holds the signer
 func (tx RawTransaction) GetSenderAddress() (string, error) {
 	for _, message := range tx.Body.Messages {
-		if message.FromAddress != "" {
-			return message.FromAddress, nil
+		var sender string
+		switch message.Type {
+		case MsgSendType:
+			sender = message.FromAddress
+		case MsgExecuteContractType, MsgTransferType:
+			sender = message.Sender
+		case MsgExecType:
+			// The grantee signs and executes messages on behalf of the granter
+			sender = message.Grantee
+		default:
+			sender = message.FromAddress
+			if sender == "" {
+				sender = message.Sender
+			}
 		}
-		if message.Sender != "" {
-			return message.Sender, nil
+		if sender != "" {
+			return sender, nil
 		}
 	}
 	return "", errors.New("no sender address found")
 }
 
-// ValidateBasic checks if the transaction contains a MsgSend as part of
-// messages a memo that contains "urn:"
+// GetMetaprotocolURN returns the metaprotocol URN found in sources.
+// URNSourceMemo is the transaction memo and URNSourceMessageMemo the memo
+// field of a message, such as an IBC MsgTransfer. A transaction may only hold
+// one URN across all sources, otherwise it is unclear which operation the
+// sender meant and an error is returned
+func (tx RawTransaction) GetMetaprotocolURN(sources []string) (string, error) {
+	var urns []string
+	for _, source := range sources {
+		switch source {
+		case URNSourceMemo:
+			if isMetaprotocolURN(tx.Body.Memo) {
+				urns = append(urns, tx.Body.Memo)
+			}
+		case URNSourceMessageMemo:
+			for _, message := range tx.Body.Messages {
+				if isMetaprotocolURN(message.Memo) {
+					urns = append(urns, message.Memo)
+				}
+			}
+		default:
+			return "", fmt.Errorf("unknown URN source '%s'", source)
+		}
+	}
+	if len(urns) == 0 {
+		return "", errors.New("transaction does not contain a metaprotocol URN")
+	}
+	if len(urns) > 1 {
+		return "", fmt.Errorf("transaction contains %d metaprotocol URNs, only one is allowed", len(urns))
+	}
+	return urns[0], nil
+}
+
+// isMetaprotocolURN returns true if value looks like a URN
+func isMetaprotocolURN(value string) bool {
+	return strings.HasPrefix(strings.ToLower(value), "urn:")
+}
+
+// ValidateBasic checks if the transaction has a message with a sender and a
+// memo that contains "urn:". Any message type may carry the memo, as long as
+// GetSenderAddress can resolve who signed it
 func (tx RawTransaction) ValidateBasic() error {
 
 	// Verify that the transaction succeeded
@@ -357,23 +425,15 @@ func (tx RawTransaction) ValidateBasic() error {
 	// 	return fmt.Errorf("transaction failed: %s", tx.TxResponse.RawLog)
 	// }
 
-	hasSend := false
-	for _, v := range tx.Body.Messages {
-		// TODO: This might need to be a IBC send
-		if v.Type == "/cosmos.bank.v1beta1.MsgSend" {
-			hasSend = true
-			// TODO: In future we can ensure that the send amount if above
-			// a certain minimum
-			break
-		}
-	}
+	_, err := tx.GetSenderAddress()
+	hasSender := err == nil
 
 	hasInscriptionMemo := false
 	if strings.Contains(strings.ToLower(tx.Body.Memo), "urn:") {
 		hasInscriptionMemo = true
 	}
 
-	if hasSend && hasInscriptionMemo {
+	if hasSender && hasInscriptionMemo {
 		return nil
 	}
 
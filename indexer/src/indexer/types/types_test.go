@@ -0,0 +1,135 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetMetaprotocolURN(t *testing.T) {
+	memoURN := "urn:cft20:cosmoshub-4@v1;mint$tic=ROIDS,amt=1"
+	messageURN := "urn:cft20:cosmoshub-4@v1;transfer$tic=ROIDS,amt=1,dst=cosmos1receiver"
+
+	var memoTx RawTransaction
+	err := json.Unmarshal([]byte(`{"body": {"messages": [{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1sender"}], "memo": "`+memoURN+`"}}`), &memoTx)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+	var messageTx RawTransaction
+	err = json.Unmarshal([]byte(`{"body": {"messages": [{"@type": "/ibc.applications.transfer.v1.MsgTransfer", "sender": "cosmos1sender", "memo": "`+messageURN+`"}], "memo": "gm"}}`), &messageTx)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+
+	var bothTx RawTransaction
+	err = json.Unmarshal([]byte(`{"body": {"messages": [{"@type": "/ibc.applications.transfer.v1.MsgTransfer", "sender": "cosmos1sender", "memo": "`+messageURN+`"}], "memo": "`+memoURN+`"}}`), &bothTx)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+	var messagesTx RawTransaction
+	err = json.Unmarshal([]byte(`{"body": {"messages": [{"@type": "/ibc.applications.transfer.v1.MsgTransfer", "sender": "cosmos1sender", "memo": "`+messageURN+`"}, {"@type": "/ibc.applications.transfer.v1.MsgTransfer", "sender": "cosmos1sender", "memo": "`+memoURN+`"}]}}`), &messagesTx)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		tx      RawTransaction
+		sources []string
+		urn     string
+	}{
+		{"memo", memoTx, []string{URNSourceMemo}, memoURN},
+		{"memo first", memoTx, []string{URNSourceMemo, URNSourceMessageMemo}, memoURN},
+		{"message memo", messageTx, []string{URNSourceMessageMemo}, messageURN},
+		{"message memo fallback", messageTx, []string{URNSourceMemo, URNSourceMessageMemo}, messageURN},
+		{"message memo not configured", messageTx, []string{URNSourceMemo}, ""},
+		{"unknown source", memoTx, []string{"extension"}, ""},
+		// Only one URN may be applied, so a transaction with several is rejected
+		{"memo and message memo", bothTx, []string{URNSourceMemo, URNSourceMessageMemo}, ""},
+		{"memo and unconfigured message memo", bothTx, []string{URNSourceMemo}, memoURN},
+		{"several message memos", messagesTx, []string{URNSourceMessageMemo}, ""},
+	}
+	for _, test := range tests {
+		urn, err := test.tx.GetMetaprotocolURN(test.sources)
+		if test.urn == "" {
+			if err == nil {
+				t.Errorf("%s: expected no URN, got '%s'", test.name, urn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected URN, got %s", test.name, err)
+			continue
+		}
+		if urn != test.urn {
+			t.Errorf("%s: expected '%s', got '%s'", test.name, test.urn, urn)
+		}
+	}
+}
+
+func TestGetSenderAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages string
+		sender   string
+	}{
+		{"MsgSend", `[{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1sender", "to_address": "cosmos1receiver"}]`, "cosmos1sender"},
+		{"MsgExecuteContract", `[{"@type": "/cosmwasm.wasm.v1.MsgExecuteContract", "sender": "cosmos1sender", "contract": "cosmos1contract"}]`, "cosmos1sender"},
+		{"MsgTransfer", `[{"@type": "/ibc.applications.transfer.v1.MsgTransfer", "sender": "cosmos1sender", "receiver": "osmo1receiver"}]`, "cosmos1sender"},
+		{"MsgExec", `[{"@type": "/cosmos.authz.v1beta1.MsgExec", "grantee": "cosmos1sender", "msgs": [{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1granter"}]}]`, "cosmos1sender"},
+		{"unknown message", `[{"@type": "/cosmos.gov.v1beta1.MsgVote", "voter": "cosmos1voter"}, {"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1sender"}]`, "cosmos1sender"},
+	}
+	for _, test := range tests {
+		var tx RawTransaction
+		err := json.Unmarshal([]byte(`{"body": {"messages": `+test.messages+`, "memo": "urn:cft20:cosmoshub-4@v1;mint$tic=ROIDS,amt=1"}}`), &tx)
+		if err != nil {
+			t.Fatalf("%s: error unmarshalling: %s", test.name, err)
+		}
+		sender, err := tx.GetSenderAddress()
+		if err != nil {
+			t.Errorf("%s: expected sender, got %s", test.name, err)
+			continue
+		}
+		if sender != test.sender {
+			t.Errorf("%s: expected sender %s, got %s", test.name, test.sender, sender)
+		}
+		if err := tx.ValidateBasic(); err != nil {
+			t.Errorf("%s: expected memo to be recognised, got %s", test.name, err)
+		}
+	}
+
+	var tx RawTransaction
+	if _, err := tx.GetSenderAddress(); err == nil {
+		t.Errorf("expected a transaction without messages to have no sender")
+	}
+}
+
+func TestValidateBasic(t *testing.T) {
+	memo := "urn:cft20:cosmoshub-4@v1;mint$tic=ROIDS,amt=1"
+	tests := []struct {
+		name     string
+		messages string
+		memo     string
+		valid    bool
+	}{
+		{"MsgSend", `[{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1sender"}]`, memo, true},
+		{"MsgExecuteContract", `[{"@type": "/cosmwasm.wasm.v1.MsgExecuteContract", "sender": "cosmos1sender"}]`, memo, true},
+		{"no messages", `[]`, memo, false},
+		{"no sender", `[{"@type": "/cosmos.gov.v1beta1.MsgVote", "voter": "cosmos1voter"}]`, memo, false},
+		{"MsgSend without sender", `[{"@type": "/cosmos.bank.v1beta1.MsgSend", "to_address": "cosmos1receiver"}]`, memo, false},
+		{"no urn", `[{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1sender"}]`, "gm", false},
+	}
+	for _, test := range tests {
+		var tx RawTransaction
+		err := json.Unmarshal([]byte(`{"body": {"messages": `+test.messages+`, "memo": "`+test.memo+`"}}`), &tx)
+		if err != nil {
+			t.Fatalf("%s: error unmarshalling: %s", test.name, err)
+		}
+		err = tx.ValidateBasic()
+		if test.valid && err != nil {
+			t.Errorf("%s: expected transaction to be valid, got %s", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected transaction to be rejected", test.name)
+		}
+	}
+}